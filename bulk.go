@@ -0,0 +1,142 @@
+package skiplist
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+// ErrNotSorted is returned, if elements for a bulk load are not in increasing order.
+var ErrNotSorted = errors.New("skiplist: elements are not sorted")
+
+// NewFromSorted returns a new Skiplist that contains the given elements.
+// The elements must be sorted in increasing order by their keys, otherwise ErrNotSorted is returned.
+// NewFromSorted runs in O(n)
+func NewFromSorted(elements []ListElement) (*SkipList, error) {
+	list := New()
+	if err := list.BulkLoad(elements); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// NewFromUnsorted returns a new Skiplist that contains the given elements in any order.
+// The elements are sorted by their keys first, equal elements keep their order.
+// NewFromUnsorted runs in O(n log(n))
+func NewFromUnsorted(elements []ListElement) *SkipList {
+	sorted := slices.Clone(elements)
+	slices.SortStableFunc(sorted, func(a, b ListElement) int {
+		return compareKeys(a.ExtractKey(), b.ExtractKey())
+	})
+
+	list := New()
+	// Can not fail, as the elements are sorted now.
+	list.BulkLoad(sorted)
+	return &list
+}
+
+func compareKeys(a, b float64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// BulkLoad appends the given elements to the end of the skiplist in one linear pass, without searching.
+// The elements must be sorted in increasing order by their keys and must not be smaller than the largest
+// element in the skiplist, otherwise ErrNotSorted is returned and the skiplist is not changed.
+// Equal elements are handled according to the duplicate policy of the skiplist.
+// BulkLoad runs in O(n)
+func (t *SkipList) BulkLoad(elements []ListElement) error {
+
+	if t == nil {
+		return nil
+	}
+
+	last := math.Inf(-1)
+	if !t.IsEmpty() {
+		last = t.endLevels[0].key
+	}
+	for _, e := range elements {
+		if e == nil {
+			continue
+		}
+		// Equal keys (eps) might be in any raw order, like Insert keeps them in insertion order.
+		key := e.ExtractKey()
+		if last-key > t.eps {
+			return ErrNotSorted
+		}
+		last = max(last, key)
+	}
+
+	lastRanks := t.startAppend()
+
+	for _, e := range elements {
+		if e == nil {
+			continue
+		}
+		key := e.ExtractKey()
+
+		if t.duplicatePolicy != AllowDuplicates && !t.IsEmpty() && math.Abs(t.endLevels[0].key-key) <= t.eps {
+			if t.duplicatePolicy == ReplaceDuplicates {
+				t.endLevels[0].value = e
+			}
+			continue
+		}
+
+		t.growHeight()
+		level := t.generateLevel(t.maxNewLevel)
+
+		// Only grow the height of the skiplist by one at a time!
+		if level > t.maxLevel {
+			level = t.maxLevel + 1
+			t.maxLevel = level
+		}
+
+		t.appendNode(newNode(level, key, e), &lastRanks)
+	}
+
+	t.finishAppend(&lastRanks)
+	return nil
+}
+
+// startAppend returns the index of the last node on every level for appendNode.
+func (t *SkipList) startAppend() (lastRanks [maxHeight]int) {
+	for i := range lastRanks {
+		lastRanks[i] = -1
+		if i <= t.maxLevel && t.endLevels[i] != nil {
+			lastRanks[i] = t.elementCount - t.endLevels[i].width[i]
+		}
+	}
+	return
+}
+
+// appendNode links elem after the last node on all of its levels in O(level of elem).
+// The widths of the links to the end are not updated, finishAppend fixes them after the last appended node.
+func (t *SkipList) appendNode(elem *SkipListElement, lastRanks *[maxHeight]int) {
+
+	index := t.elementCount
+	elem.prev = t.endLevels[0]
+
+	for i := 0; i <= elem.level; i++ {
+		*t.width(t.endLevels[i], i) = index - lastRanks[i]
+		if t.endLevels[i] == nil {
+			t.startLevels[i] = elem
+		} else {
+			t.endLevels[i].next[i] = elem
+		}
+		t.endLevels[i] = elem
+		lastRanks[i] = index
+	}
+	t.elementCount++
+}
+
+// finishAppend lets all links to the end span to the index after the last node.
+func (t *SkipList) finishAppend(lastRanks *[maxHeight]int) {
+	for i := 0; i <= t.maxLevel; i++ {
+		*t.width(t.endLevels[i], i) = t.elementCount - lastRanks[i]
+	}
+}
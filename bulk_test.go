@@ -0,0 +1,107 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestNewFromSorted(t *testing.T) {
+	elements := make([]ListElement, 0, maxN/10)
+	for i := 0; i < maxN/10; i++ {
+		elements = append(elements, Element(i/2))
+	}
+
+	list, err := NewFromSorted(elements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkStructure(t, list)
+	if list.GetNodeCount() != len(elements) {
+		t.Fail()
+	}
+	for i := 0; i < maxN/10; i += 2 {
+		if index, ok := list.Rank(Element(i / 2)); !ok || index != i {
+			t.Fail()
+		}
+	}
+
+	// The list stays fully usable.
+	list.Insert(Element(-1))
+	list.Insert(Element(17))
+	list.Delete(Element(100))
+	checkStructure(t, list)
+
+	if _, err := NewFromSorted([]ListElement{Element(1), Element(3), Element(2)}); err != ErrNotSorted {
+		t.Fail()
+	}
+
+	empty, err := NewFromSorted(nil)
+	if err != nil || !empty.IsEmpty() {
+		t.Fail()
+	}
+}
+
+func TestBulkLoad(t *testing.T) {
+	list := New()
+
+	for i := 0; i < 100; i++ {
+		list.Insert(Element(i))
+	}
+
+	// Elements smaller than the largest one are rejected without changing the list.
+	if list.BulkLoad([]ListElement{Element(100), Element(98)}) != ErrNotSorted || list.GetNodeCount() != 100 {
+		t.Fail()
+	}
+
+	var elements []ListElement
+	for i := 99; i < 1000; i++ {
+		elements = append(elements, ComplexElement{i, "bulk"})
+	}
+	if list.BulkLoad(elements) != nil {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+	if list.GetNodeCount() != 1001 || list.CountKey(Element(99)) != 2 {
+		t.Fail()
+	}
+
+	list = New()
+	list.SetDuplicatePolicy(RejectDuplicates)
+	list.BulkLoad([]ListElement{ComplexElement{1, "a"}, ComplexElement{1, "b"}, ComplexElement{2, "c"}})
+	if list.GetNodeCount() != 2 || list.GetSmallestNode().GetValue().(ComplexElement).S != "a" {
+		t.Fail()
+	}
+
+	list = New()
+	list.SetDuplicatePolicy(ReplaceDuplicates)
+	list.BulkLoad([]ListElement{ComplexElement{1, "a"}, ComplexElement{1, "b"}, ComplexElement{2, "c"}})
+	if list.GetNodeCount() != 2 || list.GetSmallestNode().GetValue().(ComplexElement).S != "b" {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+}
+
+func TestNewFromUnsorted(t *testing.T) {
+	var elements []ListElement
+	for _, e := range rand.Perm(10000) {
+		elements = append(elements, ComplexElement{e % 100, fmt.Sprint(e)})
+	}
+
+	list := NewFromUnsorted(elements)
+	checkStructure(t, list)
+	if list.GetNodeCount() != len(elements) {
+		t.Fail()
+	}
+
+	// Equal elements keep their order.
+	index := 0
+	for _, e := range elements {
+		if e.(ComplexElement).E == 42 {
+			if list.FindAll(e)[index].GetValue() != e {
+				t.Fail()
+			}
+			index++
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package skiplist
+
+import (
+	"math/rand"
+	"time"
+)
+
+// noCopy may be embedded into structs which must not be copied after the first use.
+// go vet (copylocks) reports copies of structs that contain it.
+type noCopy struct{}
+
+// Lock is a no-op used by go vet.
+func (*noCopy) Lock() {}
+
+// Unlock is a no-op used by go vet.
+func (*noCopy) Unlock() {}
+
+// Clone returns an independent deep copy of the skiplist with new nodes.
+// All nodes keep their height, so the clone has the same layout and performance as t.
+// The elements themselves (ListElement) are not copied.
+// Clone runs in O(n)
+func (t *SkipList) Clone() *SkipList {
+
+	if t == nil {
+		return nil
+	}
+
+	clone := &SkipList{
+		maxLevel: max(t.maxLevel, 0),
+		eps:      t.eps,
+		random:   rand.New(rand.NewSource(time.Now().UTC().UnixNano())),
+	}
+	clone.copySettings(t)
+
+	lastRanks := clone.startAppend()
+	for node := t.startLevels[0]; node != nil; node = node.next[0] {
+		clone.appendNode(newNode(node.level, node.key, node.value), &lastRanks)
+	}
+	clone.finishAppend(&lastRanks)
+
+	return clone
+}
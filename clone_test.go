@@ -0,0 +1,44 @@
+package skiplist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	var listPointer *SkipList
+	if listPointer.Clone() != nil {
+		t.Fail()
+	}
+
+	list := New()
+	if clone := list.Clone(); !clone.IsEmpty() {
+		t.Fail()
+	}
+
+	for _, e := range rand.Perm(1000) {
+		list.Insert(Element(e % 100))
+	}
+	list.SetDuplicatePolicy(RejectDuplicates)
+
+	clone := list.Clone()
+	checkStructure(t, clone)
+
+	// Same elements and the same tower heights.
+	if clone.String() != list.String() || clone.GetDuplicatePolicy() != RejectDuplicates {
+		t.Fail()
+	}
+
+	// Both lists are independent.
+	clone.DeleteAll(Element(50))
+	clone.Delete(Element(0))
+	list.DeleteAll(Element(99))
+	checkStructure(t, &list)
+	checkStructure(t, clone)
+	if list.CountKey(Element(50)) != 10 || clone.CountKey(Element(99)) != 10 || list.CountKey(Element(0)) != 10 {
+		t.Fail()
+	}
+	if list.GetNodeCount() != 990 || clone.GetNodeCount() != 989 {
+		t.Fail()
+	}
+}
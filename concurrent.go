@@ -0,0 +1,136 @@
+package skiplist
+
+import (
+	"sync"
+)
+
+// ConcurrentSkipList is a thread-safe wrapper around SkipList.
+// Insert, Delete and ChangeValue are protected by a write lock, while Find, FindGreaterOrEqual
+// and the iteration functions run in parallel under a read lock.
+// As nodes must not escape the lock, all functions return the actual ListElement values instead of nodes.
+type ConcurrentSkipList struct {
+	mutex sync.RWMutex
+	list  SkipList
+}
+
+// NewConcurrentEps returns a new empty, initialized thread-safe Skiplist.
+// Eps is used to compare keys given by the ExtractKey() function on equality.
+func NewConcurrentEps(eps float64) *ConcurrentSkipList {
+	return &ConcurrentSkipList{
+		list: NewEps(eps),
+	}
+}
+
+// NewConcurrent returns a new empty, initialized thread-safe Skiplist.
+func NewConcurrent() *ConcurrentSkipList {
+	return NewConcurrentEps(eps)
+}
+
+// IsEmpty checks, if the skiplist is empty.
+func (t *ConcurrentSkipList) IsEmpty() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.list.IsEmpty()
+}
+
+// GetNodeCount returns the number of nodes currently in the skiplist.
+func (t *ConcurrentSkipList) GetNodeCount() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.list.GetNodeCount()
+}
+
+// Insert inserts the given ListElement into the skiplist.
+// ok is false, if e was rejected as a duplicate.
+func (t *ConcurrentSkipList) Insert(e ListElement) (ok bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	_, ok = t.list.Insert(e)
+	return
+}
+
+// Delete removes an element equal to e from the skiplist, if there is one.
+func (t *ConcurrentSkipList) Delete(e ListElement) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.list.Delete(e)
+}
+
+// ChangeValue replaces the value of the element with the same key as newValue.
+// ok is an indicator, wether there was such an element and the value is actually changed.
+func (t *ConcurrentSkipList) ChangeValue(newValue ListElement) (ok bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if elem, found := t.list.Find(newValue); found {
+		ok = t.list.ChangeValue(elem, newValue)
+	}
+	return
+}
+
+// Find tries to find an element in the skiplist based on the key from the given ListElement.
+// value can be used, if ok is true.
+func (t *ConcurrentSkipList) Find(e ListElement) (value ListElement, ok bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	if elem, found := t.list.Find(e); found {
+		return elem.GetValue(), true
+	}
+	return
+}
+
+// FindGreaterOrEqual finds the first element, that is greater or equal to the given ListElement e.
+// value can be used, if ok is true.
+func (t *ConcurrentSkipList) FindGreaterOrEqual(e ListElement) (value ListElement, ok bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	if elem, found := t.list.FindGreaterOrEqual(e); found {
+		return elem.GetValue(), true
+	}
+	return
+}
+
+// ForEach calls f for every element in increasing order, until f returns false.
+// The whole iteration runs under one read lock, so it sees a consistent state of the skiplist.
+// f must not modify the skiplist!
+func (t *ConcurrentSkipList) ForEach(f func(e ListElement) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	for node := t.list.GetSmallestNode(); node != nil; node = node.next[0] {
+		if !f(node.value) {
+			return
+		}
+	}
+}
+
+// ForEachFrom calls f for every element that is greater or equal to e in increasing order, until f returns false.
+// The whole iteration runs under one read lock, so it sees a consistent state of the skiplist.
+// f must not modify the skiplist!
+func (t *ConcurrentSkipList) ForEachFrom(e ListElement, f func(e ListElement) bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	node, ok := t.list.FindGreaterOrEqual(e)
+	for ok && node != nil {
+		if !f(node.value) {
+			return
+		}
+		node = node.next[0]
+	}
+}
+
+// View calls f with the underlying skiplist under a read lock.
+// This allows using the node based functions (Next, Prev, ...) consistently. f must not modify the skiplist
+// and the nodes must not be used after f returns!
+func (t *ConcurrentSkipList) View(f func(list *SkipList)) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	f(&t.list)
+}
+
+// Update calls f with the underlying skiplist under a write lock.
+// This allows several modifications to be applied atomically.
+// The nodes must not be used after f returns!
+func (t *ConcurrentSkipList) Update(f func(list *SkipList)) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	f(&t.list)
+}
@@ -0,0 +1,121 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentInsertDeleteFind(t *testing.T) {
+	list := NewConcurrent()
+
+	const workers = 8
+	const n = 10000
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < n; i += workers {
+				list.Insert(Element(i))
+				if _, ok := list.Find(Element(i)); !ok {
+					t.Fail()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if list.GetNodeCount() != n {
+		t.Fail()
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < n; i += workers {
+				if i%2 == 0 {
+					list.Delete(Element(i))
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			// Iteration must always see a sorted list, even while deleting.
+			last := Element(-1)
+			list.ForEach(func(e ListElement) bool {
+				if e.(Element) <= last {
+					t.Fail()
+				}
+				last = e.(Element)
+				return true
+			})
+		}()
+	}
+	wg.Wait()
+
+	if list.GetNodeCount() != n/2 {
+		t.Fail()
+	}
+	for i := 0; i < n; i++ {
+		_, ok := list.Find(Element(i))
+		if ok != (i%2 == 1) {
+			t.Fail()
+		}
+	}
+}
+
+func TestConcurrentChangeValue(t *testing.T) {
+	list := NewConcurrent()
+
+	for i := 0; i < 100; i++ {
+		list.Insert(ComplexElement{i, "value"})
+	}
+
+	if !list.ChangeValue(ComplexElement{5, "different value"}) {
+		t.Fail()
+	}
+	if v, ok := list.Find(ComplexElement{5, ""}); !ok || v.(ComplexElement).S != "different value" {
+		t.Fail()
+	}
+	if list.ChangeValue(ComplexElement{500, "not there"}) {
+		t.Fail()
+	}
+}
+
+func TestConcurrentForEachFrom(t *testing.T) {
+	list := NewConcurrent()
+
+	for i := 0; i < 100; i++ {
+		list.Insert(Element(i))
+	}
+
+	var values []Element
+	list.ForEachFrom(Element(90), func(e ListElement) bool {
+		values = append(values, e.(Element))
+		return len(values) < 5
+	})
+	if len(values) != 5 || values[0] != 90 || values[4] != 94 {
+		t.Fail()
+	}
+
+	count := 0
+	list.ForEachFrom(Element(100), func(e ListElement) bool {
+		count++
+		return true
+	})
+	if count != 0 {
+		t.Fail()
+	}
+
+	list.Update(func(l *SkipList) {
+		l.Delete(Element(0))
+		l.Delete(Element(1))
+	})
+	list.View(func(l *SkipList) {
+		if l.GetSmallestNode().GetValue().(Element) != 2 {
+			t.Fail()
+		}
+	})
+}
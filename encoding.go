@@ -0,0 +1,187 @@
+package skiplist
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	binaryMagic   = "SKPL"
+	binaryVersion = 1
+)
+
+var (
+	// ErrNoCodec is returned, if a skiplist is serialized without a ValueCodec.
+	ErrNoCodec = errors.New("skiplist: no value codec set")
+	// ErrInvalidFormat is returned, if serialized data is truncated or corrupted.
+	ErrInvalidFormat = errors.New("skiplist: invalid serialization format")
+	// ErrUnsupportedVersion is returned, if serialized data has an unknown format version.
+	ErrUnsupportedVersion = errors.New("skiplist: unsupported serialization version")
+)
+
+// ValueCodec encodes and decodes the elements of a skiplist for serialization.
+// As ListElement is an interface, the skiplist can not know the actual type of its elements.
+type ValueCodec interface {
+	// EncodeValue returns the binary representation of e.
+	EncodeValue(e ListElement) ([]byte, error)
+	// DecodeValue returns the element for the binary representation created by EncodeValue.
+	DecodeValue(data []byte) (ListElement, error)
+}
+
+// SetValueCodec sets the codec that is used to serialize the elements of the skiplist.
+func (t *SkipList) SetValueCodec(codec ValueCodec) {
+	t.codec = codec
+}
+
+// appendElement appends the key and encoded value of one element to buf.
+func appendElement(buf []byte, codec ValueCodec, e ListElement) ([]byte, error) {
+	value, err := codec.EncodeValue(e)
+	if err != nil {
+		return nil, err
+	}
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(e.ExtractKey()))
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...), nil
+}
+
+// readElement decodes one element from the start of data and returns the remaining data.
+func readElement(data []byte, codec ValueCodec, eps float64) (ListElement, []byte, error) {
+	if len(data) < 8 {
+		return nil, nil, ErrInvalidFormat
+	}
+	key := math.Float64frombits(binary.LittleEndian.Uint64(data))
+	data = data[8:]
+
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return nil, nil, ErrInvalidFormat
+	}
+	data = data[n:]
+
+	e, err := codec.DecodeValue(data[:length])
+	if err != nil {
+		return nil, nil, err
+	}
+	// The key is saved to detect a codec that does not restore the same element.
+	if e == nil || math.Abs(e.ExtractKey()-key) > eps {
+		return nil, nil, ErrInvalidFormat
+	}
+	return e, data[length:], nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The format is versioned and saves eps, the duplicate policy and all elements in increasing order.
+// The elements are encoded with the ValueCodec of the skiplist.
+func (t *SkipList) MarshalBinary() ([]byte, error) {
+
+	if t.codec == nil {
+		return nil, ErrNoCodec
+	}
+
+	buf := make([]byte, 0, 32+16*t.elementCount)
+	buf = append(buf, binaryMagic...)
+	buf = append(buf, binaryVersion)
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(t.eps))
+	buf = append(buf, byte(t.duplicatePolicy))
+	buf = binary.AppendUvarint(buf, uint64(t.elementCount))
+
+	var err error
+	for node := t.startLevels[0]; node != nil; node = node.next[0] {
+		if buf, err = appendElement(buf, t.codec, node.value); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces all elements of the skiplist with the decoded ones, which are decoded with the ValueCodec
+// of the skiplist. The skiplist is rebuilt in linear time. If the data is invalid, the skiplist is not changed.
+func (t *SkipList) UnmarshalBinary(data []byte) error {
+
+	if t.codec == nil {
+		return ErrNoCodec
+	}
+
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrInvalidFormat
+	}
+	data = data[len(binaryMagic):]
+	if data[0] != binaryVersion {
+		return ErrUnsupportedVersion
+	}
+	data = data[1:]
+
+	if len(data) < 9 {
+		return ErrInvalidFormat
+	}
+	eps := math.Float64frombits(binary.LittleEndian.Uint64(data))
+	policy := DuplicatePolicy(data[8])
+	if policy > ReplaceDuplicates {
+		return ErrInvalidFormat
+	}
+	data = data[9:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 || count > uint64(len(data)) {
+		return ErrInvalidFormat
+	}
+	data = data[n:]
+
+	elements := make([]ListElement, 0, count)
+	for i := uint64(0); i < count; i++ {
+		e, rest, err := readElement(data, t.codec, eps)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, e)
+		data = rest
+	}
+	if len(data) != 0 {
+		return ErrInvalidFormat
+	}
+
+	return t.reload(elements, eps, policy)
+}
+
+// reload replaces all elements of the skiplist with the given sorted elements.
+// If the elements are not sorted, ErrInvalidFormat is returned and the skiplist is not changed.
+func (t *SkipList) reload(elements []ListElement, eps float64, policy DuplicatePolicy) error {
+
+	// A zero skiplist only has a codec, but needs a random source and a height to grow.
+	if t.random == nil {
+		t.random = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+		t.SetProbability(ProbabilityHalf)
+		t.SetMaxHeight(maxLevel)
+	}
+
+	list := NewEps(eps)
+	list.copySettings(t)
+	list.duplicatePolicy = AllowDuplicates
+	if err := list.BulkLoad(elements); err != nil {
+		return ErrInvalidFormat
+	}
+
+	t.startLevels = list.startLevels
+	t.endLevels = list.endLevels
+	t.startWidth = list.startWidth
+	t.maxLevel = list.maxLevel
+	t.elementCount = list.elementCount
+	t.eps = eps
+	t.duplicatePolicy = policy
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder with the same format as MarshalBinary.
+func (t *SkipList) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder with the same format as UnmarshalBinary.
+// The ValueCodec must be set on the skiplist before decoding.
+func (t *SkipList) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
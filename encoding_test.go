@@ -0,0 +1,119 @@
+package skiplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+// complexCodec encodes ComplexElement values.
+type complexCodec struct{}
+
+func (complexCodec) EncodeValue(e ListElement) ([]byte, error) {
+	c, ok := e.(ComplexElement)
+	if !ok {
+		return nil, errors.New("not a ComplexElement")
+	}
+	buf := binary.AppendVarint(nil, int64(c.E))
+	return append(buf, c.S...), nil
+}
+
+func (complexCodec) DecodeValue(data []byte) (ListElement, error) {
+	e, n := binary.Varint(data)
+	if n <= 0 {
+		return nil, errors.New("invalid ComplexElement")
+	}
+	return ComplexElement{int(e), string(data[n:])}, nil
+}
+
+func TestMarshalBinary(t *testing.T) {
+	list := NewEps(0.5)
+	list.SetDuplicatePolicy(RejectDuplicates)
+
+	if _, err := list.MarshalBinary(); err != ErrNoCodec {
+		t.Fail()
+	}
+	list.SetValueCodec(complexCodec{})
+
+	for i := 0; i < 1000; i++ {
+		list.Insert(ComplexElement{i, "value"})
+	}
+
+	data, err := list.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded SkipList
+	if decoded.UnmarshalBinary(data) != ErrNoCodec {
+		t.Fail()
+	}
+	decoded.SetValueCodec(complexCodec{})
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	checkStructure(t, &decoded)
+
+	if decoded.GetNodeCount() != 1000 || decoded.eps != 0.5 || decoded.GetDuplicatePolicy() != RejectDuplicates {
+		t.Fail()
+	}
+	index := 0
+	for v := range decoded.All() {
+		if v.(ComplexElement) != (ComplexElement{index, "value"}) {
+			t.Fail()
+		}
+		index++
+	}
+
+	// The decoded list is fully usable.
+	if _, ok := decoded.Insert(ComplexElement{5, ""}); ok {
+		t.Fail()
+	}
+	decoded.Insert(ComplexElement{1000, ""})
+	checkStructure(t, &decoded)
+
+	// Truncated and corrupted data does not change the list.
+	for _, broken := range [][]byte{nil, data[:3], data[:10], data[:len(data)-1], append(data[:len(data):len(data)], 0)} {
+		if err := decoded.UnmarshalBinary(broken); err != ErrInvalidFormat {
+			t.Errorf("expected ErrInvalidFormat, got %v", err)
+		}
+	}
+	wrongVersion := bytes.Clone(data)
+	wrongVersion[4] = 99
+	if decoded.UnmarshalBinary(wrongVersion) != ErrUnsupportedVersion {
+		t.Fail()
+	}
+	wrongPolicy := bytes.Clone(data)
+	wrongPolicy[13] = 7
+	if decoded.UnmarshalBinary(wrongPolicy) != ErrInvalidFormat {
+		t.Fail()
+	}
+	if decoded.GetNodeCount() != 1001 {
+		t.Fail()
+	}
+}
+
+func TestGob(t *testing.T) {
+	list := New()
+	list.SetValueCodec(complexCodec{})
+	for i := 0; i < 100; i++ {
+		list.Insert(ComplexElement{i % 10, "value"})
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&list); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := New()
+	decoded.SetValueCodec(complexCodec{})
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	checkStructure(t, &decoded)
+	if decoded.GetNodeCount() != 100 || decoded.CountKey(Element(3)) != 10 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,425 @@
+package skiplist
+
+import (
+	"cmp"
+	"math/bits"
+	"math/rand"
+	"time"
+)
+
+// GenericSkipListElement represents one actual Node in the generic skiplist structure.
+// It saves the key and value separately, pointers to the next nodes and a pointer to one previous node.
+type GenericSkipListElement[K any, V any] struct {
+	next  [maxLevel]*GenericSkipListElement[K, V]
+	level int
+	key   K
+	value V
+	prev  *GenericSkipListElement[K, V]
+}
+
+// GenericSkipList is a type-parameterised skiplist that stores keys and values separately.
+// Keys are ordered by a compare function, so there is no eps and no loss of precision
+// for int64 or string keys. Any key type can be used, as long as a compare function is given.
+type GenericSkipList[K any, V any] struct {
+	startLevels  [maxLevel]*GenericSkipListElement[K, V]
+	endLevels    [maxLevel]*GenericSkipListElement[K, V]
+	maxNewLevel  int
+	maxLevel     int
+	elementCount int
+	compare      func(a, b K) int
+	random       *rand.Rand
+}
+
+// NewGenericSeedFunc returns a new empty, initialized generic Skiplist.
+// Given a seed, a deterministic height/list behaviour can be achieved.
+// compare is used for all searches, insertions and deletions. It returns a negative number if a < b,
+// a positive number if a > b and zero, if both keys are equal (Like bytes.Compare or cmp.Compare).
+func NewGenericSeedFunc[K any, V any](seed int64, compare func(a, b K) int) GenericSkipList[K, V] {
+
+	list := GenericSkipList[K, V]{
+		startLevels:  [maxLevel]*GenericSkipListElement[K, V]{},
+		endLevels:    [maxLevel]*GenericSkipListElement[K, V]{},
+		maxNewLevel:  maxLevel,
+		maxLevel:     0,
+		elementCount: 0,
+		compare:      compare,
+		random:       rand.New(rand.NewSource(seed)),
+	}
+
+	return list
+}
+
+// NewGenericFunc returns a new empty, initialized generic Skiplist that orders its keys with compare.
+// This allows keys like []byte, composite keys or case-insensitive strings.
+func NewGenericFunc[K any, V any](compare func(a, b K) int) GenericSkipList[K, V] {
+	return NewGenericSeedFunc[K, V](time.Now().UTC().UnixNano(), compare)
+}
+
+// NewGenericSeed returns a new empty, initialized generic Skiplist with naturally ordered keys.
+// Given a seed, a deterministic height/list behaviour can be achieved.
+func NewGenericSeed[K cmp.Ordered, V any](seed int64) GenericSkipList[K, V] {
+	return NewGenericSeedFunc[K, V](seed, cmp.Compare[K])
+}
+
+// NewGeneric returns a new empty, initialized generic Skiplist with naturally ordered keys.
+func NewGeneric[K cmp.Ordered, V any]() GenericSkipList[K, V] {
+	return NewGenericSeed[K, V](time.Now().UTC().UnixNano())
+}
+
+// IsEmpty checks, if the skiplist is empty.
+func (t *GenericSkipList[K, V]) IsEmpty() bool {
+	return t.startLevels[0] == nil
+}
+
+func (t *GenericSkipList[K, V]) generateLevel(maxLevel int) int {
+	level := maxLevel - 1
+	// First we apply some mask which makes sure that we don't get a level
+	// above our desired level. Then we find the first set bit.
+	var x uint64 = t.random.Uint64() & ((1 << uint(maxLevel-1)) - 1)
+	zeroes := bits.TrailingZeros64(x)
+	if zeroes < maxLevel {
+		level = zeroes
+	}
+
+	return level
+}
+
+func (t *GenericSkipList[K, V]) findEntryIndex(key K, level int) int {
+	// Find good entry point so we don't accidentally skip half the list.
+	for i := t.maxLevel; i >= 0; i-- {
+		if t.startLevels[i] != nil && t.compare(t.startLevels[i].key, key) <= 0 || i <= level {
+			return i
+		}
+	}
+	return 0
+}
+
+func (t *GenericSkipList[K, V]) findExtended(key K, findGreaterOrEqual bool) (foundElem *GenericSkipListElement[K, V], ok bool) {
+
+	foundElem = nil
+	ok = false
+
+	if t.IsEmpty() {
+		return
+	}
+
+	index := t.findEntryIndex(key, 0)
+	var currentNode *GenericSkipListElement[K, V]
+
+	currentNode = t.startLevels[index]
+	nextNode := currentNode
+
+	// In case, that our first element is already greater-or-equal!
+	if findGreaterOrEqual && t.compare(currentNode.key, key) > 0 {
+		foundElem = currentNode
+		ok = true
+		return
+	}
+
+	for {
+		if t.compare(currentNode.key, key) == 0 {
+			foundElem = currentNode
+			ok = true
+			return
+		}
+
+		nextNode = currentNode.next[index]
+
+		// Which direction are we continuing next time?
+		if nextNode != nil && t.compare(nextNode.key, key) <= 0 {
+			// Go right
+			currentNode = nextNode
+		} else {
+			if index > 0 {
+
+				// Early exit
+				if currentNode.next[0] != nil && t.compare(currentNode.next[0].key, key) == 0 {
+					foundElem = currentNode.next[0]
+					ok = true
+					return
+				}
+				// Go down
+				index--
+			} else {
+				// Element is not found and we reached the bottom.
+				if findGreaterOrEqual {
+					foundElem = nextNode
+					ok = nextNode != nil
+				}
+
+				return
+			}
+		}
+	}
+}
+
+// Find tries to find an element in the skiplist with the given key.
+// elem can be used, if ok is true.
+// Find runs in approx. O(log(n))
+func (t *GenericSkipList[K, V]) Find(key K) (elem *GenericSkipListElement[K, V], ok bool) {
+
+	if t == nil {
+		return
+	}
+
+	elem, ok = t.findExtended(key, false)
+	return
+}
+
+// FindGreaterOrEqual finds the first element, that has a key greater or equal to the given key.
+// FindGreaterOrEqual runs in approx. O(log(n))
+func (t *GenericSkipList[K, V]) FindGreaterOrEqual(key K) (elem *GenericSkipListElement[K, V], ok bool) {
+
+	if t == nil {
+		return
+	}
+
+	elem, ok = t.findExtended(key, true)
+	return
+}
+
+// Delete removes an element with the given key from the skiplist, if there is one.
+// If there are multiple entries with the same key, Delete will remove the one that was inserted first.
+// Delete runs in approx. O(log(n))
+func (t *GenericSkipList[K, V]) Delete(key K) {
+
+	if t == nil || t.IsEmpty() {
+		return
+	}
+
+	// Remember the last node before the key on every level, so that exactly
+	// one node is unlinked on all of its levels, even with duplicate keys.
+	var update [maxLevel]*GenericSkipListElement[K, V]
+	var currentNode *GenericSkipListElement[K, V]
+
+	for index := t.findEntryIndex(key, 0); index >= 0; index-- {
+		for {
+			var nextNode *GenericSkipListElement[K, V]
+			if currentNode == nil {
+				nextNode = t.startLevels[index]
+			} else {
+				nextNode = currentNode.next[index]
+			}
+			if nextNode == nil || t.compare(nextNode.key, key) >= 0 {
+				break
+			}
+			// Go right
+			currentNode = nextNode
+		}
+		update[index] = currentNode
+	}
+
+	node := t.startLevels[0]
+	if currentNode != nil {
+		node = currentNode.next[0]
+	}
+	if node == nil || t.compare(node.key, key) != 0 {
+		return
+	}
+
+	if node.next[0] != nil {
+		node.next[0].prev = node.prev
+	}
+	node.prev = nil
+
+	for index := 0; index <= node.level; index++ {
+
+		if update[index] == nil {
+			t.startLevels[index] = node.next[index]
+		} else {
+			update[index].next[index] = node.next[index]
+		}
+
+		// Link from end needs readjustments.
+		if node.next[index] == nil {
+			t.endLevels[index] = update[index]
+		}
+		node.next[index] = nil
+	}
+
+	// The highest levels might be empty now.
+	for t.maxLevel >= 0 && t.startLevels[t.maxLevel] == nil {
+		t.maxLevel--
+	}
+
+	t.elementCount--
+}
+
+// Insert inserts the given key and value into the skiplist.
+// Insert runs in approx. O(log(n))
+func (t *GenericSkipList[K, V]) Insert(key K, value V) {
+
+	if t == nil {
+		return
+	}
+
+	level := t.generateLevel(t.maxNewLevel)
+
+	// Only grow the height of the skiplist by one at a time!
+	if level > t.maxLevel {
+		level = t.maxLevel + 1
+		t.maxLevel = level
+	}
+
+	elem := &GenericSkipListElement[K, V]{
+		next:  [maxLevel]*GenericSkipListElement[K, V]{},
+		level: level,
+		key:   key,
+		value: value,
+	}
+
+	t.elementCount++
+
+	newFirst := true
+	newLast := true
+	if !t.IsEmpty() {
+		newFirst = t.compare(elem.key, t.startLevels[0].key) < 0
+		newLast = t.compare(elem.key, t.endLevels[0].key) > 0
+	}
+
+	normallyInserted := false
+	if !newFirst && !newLast {
+
+		normallyInserted = true
+
+		index := t.findEntryIndex(elem.key, level)
+
+		var currentNode *GenericSkipListElement[K, V]
+		nextNode := t.startLevels[index]
+
+		for {
+
+			if currentNode == nil {
+				nextNode = t.startLevels[index]
+			} else {
+				nextNode = currentNode.next[index]
+			}
+
+			// Connect node to next
+			if index <= level && (nextNode == nil || t.compare(nextNode.key, elem.key) > 0) {
+				elem.next[index] = nextNode
+				if currentNode != nil {
+					currentNode.next[index] = elem
+				}
+				if index == 0 {
+					elem.prev = currentNode
+					if nextNode != nil {
+						nextNode.prev = elem
+					}
+				}
+			}
+
+			if nextNode != nil && t.compare(nextNode.key, elem.key) <= 0 {
+				// Go right
+				currentNode = nextNode
+			} else {
+				// Go down
+				index--
+				if index < 0 {
+					break
+				}
+			}
+		}
+	}
+
+	// Where we have a left-most position that needs to be referenced!
+	for i := level; i >= 0; i-- {
+
+		didSomething := false
+
+		if newFirst || normallyInserted {
+
+			if t.startLevels[i] == nil || t.compare(t.startLevels[i].key, elem.key) > 0 {
+				if i == 0 && t.startLevels[i] != nil {
+					t.startLevels[i].prev = elem
+				}
+				elem.next[i] = t.startLevels[i]
+				t.startLevels[i] = elem
+			}
+
+			// link the endLevels to this element!
+			if elem.next[i] == nil {
+				t.endLevels[i] = elem
+			}
+
+			didSomething = true
+		}
+
+		if newLast {
+			// Places the element after the very last element on this level!
+			// This is very important, so we are not linking the very first element (newFirst AND newLast) to itself!
+			if !newFirst {
+				if t.endLevels[i] != nil {
+					t.endLevels[i].next[i] = elem
+				}
+				if i == 0 {
+					elem.prev = t.endLevels[i]
+				}
+				t.endLevels[i] = elem
+			}
+
+			// Link the startLevels to this element!
+			if t.startLevels[i] == nil || t.compare(t.startLevels[i].key, elem.key) > 0 {
+				t.startLevels[i] = elem
+			}
+
+			didSomething = true
+		}
+
+		if !didSomething {
+			break
+		}
+	}
+}
+
+// GetKey extracts the key from a generic skiplist node.
+func (e *GenericSkipListElement[K, V]) GetKey() K {
+	return e.key
+}
+
+// GetValue extracts the value from a generic skiplist node.
+func (e *GenericSkipListElement[K, V]) GetValue() V {
+	return e.value
+}
+
+// GetSmallestNode returns the very first/smallest node in the skiplist.
+// GetSmallestNode runs in O(1)
+func (t *GenericSkipList[K, V]) GetSmallestNode() *GenericSkipListElement[K, V] {
+	return t.startLevels[0]
+}
+
+// GetLargestNode returns the very last/largest node in the skiplist.
+// GetLargestNode runs in O(1)
+func (t *GenericSkipList[K, V]) GetLargestNode() *GenericSkipListElement[K, V] {
+	return t.endLevels[0]
+}
+
+// Next returns the next element based on the given node.
+// Next will loop around to the first node, if you call it on the last!
+func (t *GenericSkipList[K, V]) Next(e *GenericSkipListElement[K, V]) *GenericSkipListElement[K, V] {
+	if e.next[0] == nil {
+		return t.startLevels[0]
+	}
+	return e.next[0]
+}
+
+// Prev returns the previous element based on the given node.
+// Prev will loop around to the last node, if you call it on the first!
+func (t *GenericSkipList[K, V]) Prev(e *GenericSkipListElement[K, V]) *GenericSkipListElement[K, V] {
+	if e.prev == nil {
+		return t.endLevels[0]
+	}
+	return e.prev
+}
+
+// GetNodeCount returns the number of nodes currently in the skiplist.
+func (t *GenericSkipList[K, V]) GetNodeCount() int {
+	return t.elementCount
+}
+
+// ChangeValue can be used to change the value of a node in the skiplist
+// without the need of Deleting and reinserting the node again.
+// The key of the node always stays the same.
+func (t *GenericSkipList[K, V]) ChangeValue(e *GenericSkipListElement[K, V], newValue V) {
+	e.value = newValue
+}
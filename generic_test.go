@@ -0,0 +1,191 @@
+package skiplist
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestGenericInsertAndFind(t *testing.T) {
+	var listPointer *GenericSkipList[int64, string]
+	listPointer.Insert(0, "")
+	if _, ok := listPointer.Find(0); ok {
+		t.Fail()
+	}
+
+	list := NewGeneric[int64, int]()
+
+	if _, ok := list.Find(0); ok {
+		t.Fail()
+	}
+	if !list.IsEmpty() {
+		t.Fail()
+	}
+
+	// Keys above 2^53 can not be represented exactly as float64.
+	const base = int64(1) << 60
+	rList := rand.Perm(maxN / 10)
+	for _, e := range rList {
+		list.Insert(base+int64(e), e)
+	}
+	for _, e := range rList {
+		if v, ok := list.Find(base + int64(e)); !ok || v.GetValue() != e || v.GetKey() != base+int64(e) {
+			t.Fail()
+		}
+	}
+	if list.GetNodeCount() != len(rList) {
+		t.Fail()
+	}
+	if _, ok := list.Find(base - 1); ok {
+		t.Fail()
+	}
+}
+
+func TestGenericDelete(t *testing.T) {
+	list := NewGeneric[string, int]()
+
+	list.Delete("a")
+
+	keys := []string{"pear", "apple", "fig", "banana", "kiwi", "cherry"}
+	for i, k := range keys {
+		list.Insert(k, i)
+	}
+	for _, k := range keys {
+		list.Delete(k)
+		if _, ok := list.Find(k); ok {
+			t.Fail()
+		}
+	}
+	if !list.IsEmpty() || list.GetNodeCount() != 0 {
+		t.Fail()
+	}
+
+	rList := rand.Perm(maxN / 10)
+	for _, e := range rList {
+		list.Insert(string(rune(e)), e)
+	}
+	for _, e := range rList {
+		list.Delete(string(rune(e)))
+	}
+	if !list.IsEmpty() {
+		t.Fail()
+	}
+}
+
+func TestGenericDuplicates(t *testing.T) {
+	list := NewGeneric[int, int]()
+
+	for i := 0; i < 1000; i++ {
+		list.Insert(i%10, i)
+	}
+	for i := 0; i < 1000; i++ {
+		list.Delete(i % 10)
+	}
+	if !list.IsEmpty() || list.GetNodeCount() != 0 {
+		t.Fail()
+	}
+}
+
+func TestGenericFindGreaterOrEqual(t *testing.T) {
+	list := NewGeneric[int, int]()
+
+	if _, ok := list.FindGreaterOrEqual(0); ok {
+		t.Fail()
+	}
+
+	for i := 0; i < 1000; i++ {
+		list.Insert(i*2, i)
+	}
+	for i := -1; i < 1998; i++ {
+		v, ok := list.FindGreaterOrEqual(i)
+		if !ok {
+			t.Fail()
+			continue
+		}
+		if v.GetKey() < i || v.GetKey()-i > 1 {
+			t.Fail()
+		}
+	}
+	if _, ok := list.FindGreaterOrEqual(1999); ok {
+		t.Fail()
+	}
+}
+
+func TestGenericNextPrev(t *testing.T) {
+	list := NewGeneric[int, int]()
+
+	for _, e := range rand.Perm(1000) {
+		list.Insert(e, e)
+	}
+
+	smallest := list.GetSmallestNode()
+	largest := list.GetLargestNode()
+
+	node := smallest
+	for i := 0; i < 1000; i++ {
+		if node.GetKey() != i {
+			t.Fail()
+		}
+		if list.Prev(list.Next(node)) != node {
+			t.Fail()
+		}
+		node = list.Next(node)
+	}
+	if node != smallest || list.Prev(smallest) != largest {
+		t.Fail()
+	}
+
+	list.ChangeValue(largest, -1)
+	if v, _ := list.Find(999); v.GetValue() != -1 {
+		t.Fail()
+	}
+}
+
+func TestGenericCompareFunc(t *testing.T) {
+	list := NewGenericFunc[[]byte, int](bytes.Compare)
+
+	rList := rand.Perm(1000)
+	for _, e := range rList {
+		list.Insert([]byte(fmt.Sprintf("key%04d", e)), e)
+	}
+	for _, e := range rList {
+		if v, ok := list.Find([]byte(fmt.Sprintf("key%04d", e))); !ok || v.GetValue() != e {
+			t.Fail()
+		}
+	}
+	if v, ok := list.FindGreaterOrEqual([]byte("key0500a")); !ok || v.GetValue() != 501 {
+		t.Fail()
+	}
+	for _, e := range rList {
+		list.Delete([]byte(fmt.Sprintf("key%04d", e)))
+	}
+	if !list.IsEmpty() {
+		t.Fail()
+	}
+
+	// Case-insensitive keys.
+	caseList := NewGenericFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	caseList.Insert("Hello", 1)
+	caseList.Insert("WORLD", 2)
+	if v, ok := caseList.Find("world"); !ok || v.GetValue() != 2 {
+		t.Fail()
+	}
+	caseList.Delete("hello")
+	if _, ok := caseList.Find("HELLO"); ok {
+		t.Fail()
+	}
+}
+
+func TestGenericGenerateLevelLimit(t *testing.T) {
+	list := NewGeneric[int, int]()
+	list.random = rand.New(zeroSource{})
+	for _, height := range []int{1, 2, maxLevel} {
+		if level := list.generateLevel(height); level != height-1 {
+			t.Errorf("level %v for height %v", level, height)
+		}
+	}
+}
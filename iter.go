@@ -0,0 +1,110 @@
+package skiplist
+
+import (
+	"iter"
+)
+
+// All returns an iterator over all elements in increasing order.
+// Unlike Next, the iterator stops after the largest element.
+func (t *SkipList) All() iter.Seq[ListElement] {
+	return func(yield func(ListElement) bool) {
+		if t == nil {
+			return
+		}
+		for node := t.startLevels[0]; node != nil; node = node.next[0] {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over all elements in decreasing order.
+// Unlike Prev, the iterator stops after the smallest element.
+func (t *SkipList) Backward() iter.Seq[ListElement] {
+	return func(yield func(ListElement) bool) {
+		if t == nil {
+			return
+		}
+		for node := t.endLevels[0]; node != nil; node = node.prev {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// From returns an iterator over all elements greater or equal to e in increasing order.
+// Finding the first element runs in approx. O(log(n))
+func (t *SkipList) From(e ListElement) iter.Seq[ListElement] {
+	return func(yield func(ListElement) bool) {
+		if t == nil || e == nil {
+			return
+		}
+		for node := t.nextNode(t.findLast(e.ExtractKey(), false), 0); node != nil; node = node.next[0] {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Indexed returns an iterator over all indices and elements in increasing order.
+func (t *SkipList) Indexed() iter.Seq2[int, ListElement] {
+	return func(yield func(int, ListElement) bool) {
+		if t == nil {
+			return
+		}
+		index := 0
+		for node := t.startLevels[0]; node != nil; node = node.next[0] {
+			if !yield(index, node.value) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// All returns an iterator over all keys and values in increasing order.
+// Unlike Next, the iterator stops after the largest element.
+func (t *GenericSkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t == nil {
+			return
+		}
+		for node := t.startLevels[0]; node != nil; node = node.next[0] {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over all keys and values in decreasing order.
+// Unlike Prev, the iterator stops after the smallest element.
+func (t *GenericSkipList[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t == nil {
+			return
+		}
+		for node := t.endLevels[0]; node != nil; node = node.prev {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// From returns an iterator over all keys and values with a key greater or equal to key in increasing order.
+// Finding the first element runs in approx. O(log(n))
+func (t *GenericSkipList[K, V]) From(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		node, ok := t.FindGreaterOrEqual(key)
+		for ok && node != nil {
+			if !yield(node.key, node.value) {
+				return
+			}
+			node = node.next[0]
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package skiplist
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	list := New()
+
+	if len(slices.Collect(list.All())) != 0 || len(slices.Collect(list.Backward())) != 0 {
+		t.Fail()
+	}
+
+	for i := 0; i < 100; i++ {
+		list.Insert(Element(i))
+	}
+
+	i := 0
+	for v := range list.All() {
+		if v.(Element) != Element(i) {
+			t.Fail()
+		}
+		i++
+	}
+	if i != 100 {
+		t.Fail()
+	}
+
+	i = 99
+	for v := range list.Backward() {
+		if v.(Element) != Element(i) {
+			t.Fail()
+		}
+		i--
+	}
+	if i != -1 {
+		t.Fail()
+	}
+
+	values := slices.Collect(list.From(FloatElement(89.5)))
+	if len(values) != 10 || values[0].(Element) != 90 {
+		t.Fail()
+	}
+	if len(slices.Collect(list.From(Element(100)))) != 0 {
+		t.Fail()
+	}
+
+	// Early break.
+	count := 0
+	for range list.All() {
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	if count != 5 {
+		t.Fail()
+	}
+
+	for index, v := range list.Indexed() {
+		if v.(Element) != Element(index) {
+			t.Fail()
+		}
+	}
+}
+
+func TestGenericIterators(t *testing.T) {
+	list := NewGeneric[string, int]()
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	for k, v := range m {
+		list.Insert(k, v)
+	}
+
+	if !maps.Equal(maps.Collect(list.All()), m) {
+		t.Fail()
+	}
+
+	var keys []string
+	for k := range list.Backward() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"d", "c", "b", "a"}) {
+		t.Fail()
+	}
+
+	keys = keys[:0]
+	for k, v := range list.From("b") {
+		keys = append(keys, k)
+		if v != m[k] {
+			t.Fail()
+		}
+		if k == "c" {
+			break
+		}
+	}
+	if !slices.Equal(keys, []string{"b", "c"}) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,97 @@
+package skiplist
+
+// Iterator is a cursor over the skiplist in the style of LevelDB iterators.
+// Unlike Next and Prev on the skiplist, the iterator does not loop around, but becomes invalid at the ends.
+// A new iterator is invalid until it is positioned with First, Last, SeekGE or SeekLT.
+// The iterator stays usable, as long as the node it points to is not deleted.
+type Iterator struct {
+	list *SkipList
+	node *SkipListElement
+}
+
+// NewIterator returns a new, unpositioned iterator over the skiplist.
+func (t *SkipList) NewIterator() *Iterator {
+	return &Iterator{
+		list: t,
+	}
+}
+
+// Valid checks, if the iterator is positioned at an element.
+func (it *Iterator) Valid() bool {
+	return it.node != nil
+}
+
+// Key returns the key of the current element. The iterator must be valid.
+func (it *Iterator) Key() float64 {
+	return it.node.key
+}
+
+// Value returns the current element. The iterator must be valid.
+func (it *Iterator) Value() ListElement {
+	return it.node.value
+}
+
+// Node returns the current skiplist node or nil, if the iterator is invalid.
+func (it *Iterator) Node() *SkipListElement {
+	return it.node
+}
+
+// First moves the iterator to the smallest element.
+// It returns, if the iterator is valid afterwards.
+func (it *Iterator) First() bool {
+	it.node = nil
+	if it.list != nil {
+		it.node = it.list.startLevels[0]
+	}
+	return it.Valid()
+}
+
+// Last moves the iterator to the largest element.
+// It returns, if the iterator is valid afterwards.
+func (it *Iterator) Last() bool {
+	it.node = nil
+	if it.list != nil {
+		it.node = it.list.endLevels[0]
+	}
+	return it.Valid()
+}
+
+// SeekGE moves the iterator to the first element that is greater or equal to e.
+// It returns, if the iterator is valid afterwards.
+// SeekGE runs in approx. O(log(n))
+func (it *Iterator) SeekGE(e ListElement) bool {
+	it.node = nil
+	if it.list != nil && e != nil {
+		it.node = it.list.nextNode(it.list.findLast(e.ExtractKey(), false), 0)
+	}
+	return it.Valid()
+}
+
+// SeekLT moves the iterator to the last element that is smaller than e.
+// It returns, if the iterator is valid afterwards.
+// SeekLT runs in approx. O(log(n))
+func (it *Iterator) SeekLT(e ListElement) bool {
+	it.node = nil
+	if it.list != nil && e != nil {
+		it.node = it.list.findLast(e.ExtractKey(), false)
+	}
+	return it.Valid()
+}
+
+// Next moves the iterator to the next element.
+// It returns false and becomes invalid, if there is no next element.
+func (it *Iterator) Next() bool {
+	if it.node != nil {
+		it.node = it.node.next[0]
+	}
+	return it.Valid()
+}
+
+// Prev moves the iterator to the previous element.
+// It returns false and becomes invalid, if there is no previous element.
+func (it *Iterator) Prev() bool {
+	if it.node != nil {
+		it.node = it.node.prev
+	}
+	return it.Valid()
+}
@@ -0,0 +1,74 @@
+package skiplist
+
+import (
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	list := New()
+	it := list.NewIterator()
+
+	if it.Valid() || it.First() || it.Last() || it.SeekGE(Element(0)) || it.SeekLT(Element(0)) || it.Next() || it.Prev() {
+		t.Fail()
+	}
+
+	for i := 0; i < 100; i += 2 {
+		list.Insert(Element(i))
+	}
+
+	count := 0
+	for ok := it.First(); ok; ok = it.Next() {
+		if it.Value().(Element) != Element(count*2) || it.Key() != float64(count*2) {
+			t.Fail()
+		}
+		count++
+	}
+	// No wrapping around at the end.
+	if count != 50 || it.Valid() || it.Node() != nil {
+		t.Fail()
+	}
+
+	count = 0
+	for ok := it.Last(); ok; ok = it.Prev() {
+		count++
+	}
+	if count != 50 || it.Valid() {
+		t.Fail()
+	}
+
+	if !it.SeekGE(Element(11)) || it.Value().(Element) != 12 {
+		t.Fail()
+	}
+	if !it.SeekGE(Element(12)) || it.Value().(Element) != 12 {
+		t.Fail()
+	}
+	if !it.SeekLT(Element(12)) || it.Value().(Element) != 10 {
+		t.Fail()
+	}
+	if !it.SeekLT(Element(13)) || it.Value().(Element) != 12 {
+		t.Fail()
+	}
+	if it.SeekGE(Element(99)) || it.SeekLT(Element(0)) {
+		t.Fail()
+	}
+	if !it.SeekGE(Element(-5)) || it.Value().(Element) != 0 || it.Prev() {
+		t.Fail()
+	}
+	if !it.SeekLT(Element(500)) || it.Value().(Element) != 98 || it.Next() {
+		t.Fail()
+	}
+}
+
+func TestIteratorInfiniteLoop(t *testing.T) {
+	list := New()
+	list.Insert(Element(1))
+
+	it := list.NewIterator()
+	count := 0
+	for it.SeekGE(Element(0)); it.Valid(); it.Next() {
+		count++
+	}
+	if count != 1 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,100 @@
+package skiplist
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+)
+
+// ErrNoFactory is returned, if a skiplist is decoded from JSON without an element factory.
+var ErrNoFactory = errors.New("skiplist: no element factory set")
+
+// JSONOptions configures the JSON representation of a skiplist.
+type JSONOptions struct {
+	// WithKeys writes every element as {"key": ..., "value": ...} instead of only the value.
+	WithKeys bool
+	// Factory creates an element from its JSON value. It is required for UnmarshalJSON.
+	Factory func(data json.RawMessage) (ListElement, error)
+}
+
+// jsonElement is the JSON representation of an element with its key.
+type jsonElement struct {
+	Key   float64         `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// SetJSONOptions configures the JSON representation of the skiplist.
+func (t *SkipList) SetJSONOptions(opts JSONOptions) {
+	t.jsonOptions = opts
+}
+
+// MarshalJSON implements json.Marshaler.
+// The skiplist is written as an array of all elements in increasing order, including equal ones.
+func (t *SkipList) MarshalJSON() ([]byte, error) {
+
+	buf := []byte{'['}
+	for node := t.startLevels[0]; node != nil; node = node.next[0] {
+		if node != t.startLevels[0] {
+			buf = append(buf, ',')
+		}
+
+		var data []byte
+		var err error
+		if t.jsonOptions.WithKeys {
+			var value []byte
+			if value, err = json.Marshal(node.value); err == nil {
+				data, err = json.Marshal(jsonElement{node.key, value})
+			}
+		} else {
+			data, err = json.Marshal(node.value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, data...)
+	}
+	return append(buf, ']'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It replaces all elements of the skiplist with the decoded ones, which are created by the element factory
+// from the JSON options. Equal elements keep their order. If the data is invalid, the skiplist is not changed.
+func (t *SkipList) UnmarshalJSON(data []byte) error {
+
+	if t.jsonOptions.Factory == nil {
+		return ErrNoFactory
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	elements := make([]ListElement, 0, len(values))
+	for _, value := range values {
+		var keyed jsonElement
+		if t.jsonOptions.WithKeys {
+			if err := json.Unmarshal(value, &keyed); err != nil {
+				return err
+			}
+			value = keyed.Value
+		}
+
+		e, err := t.jsonOptions.Factory(value)
+		if err != nil {
+			return err
+		}
+		if e == nil || t.jsonOptions.WithKeys && math.Abs(e.ExtractKey()-keyed.Key) > t.eps {
+			return ErrInvalidFormat
+		}
+		elements = append(elements, e)
+	}
+
+	// Hand written JSON might not be sorted.
+	slices.SortStableFunc(elements, func(a, b ListElement) int {
+		return compareKeys(a.ExtractKey(), b.ExtractKey())
+	})
+
+	return t.reload(elements, t.eps, t.duplicatePolicy)
+}
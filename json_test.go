@@ -0,0 +1,82 @@
+package skiplist
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func complexFactory(data json.RawMessage) (ListElement, error) {
+	var e ComplexElement
+	err := json.Unmarshal(data, &e)
+	return e, err
+}
+
+func TestMarshalJSON(t *testing.T) {
+	list := New()
+
+	data, err := json.Marshal(&list)
+	if err != nil || string(data) != "[]" {
+		t.Fail()
+	}
+
+	list.Insert(ComplexElement{2, "b"})
+	list.Insert(ComplexElement{1, "a"})
+	list.Insert(ComplexElement{2, "c"})
+
+	data, err = json.Marshal(&list)
+	if err != nil || string(data) != `[{"E":1,"S":"a"},{"E":2,"S":"b"},{"E":2,"S":"c"}]` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+
+	list.SetJSONOptions(JSONOptions{WithKeys: true})
+	data, err = json.Marshal(&list)
+	if err != nil || string(data) != `[{"key":1,"value":{"E":1,"S":"a"}},{"key":2,"value":{"E":2,"S":"b"}},{"key":2,"value":{"E":2,"S":"c"}}]` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	list := New()
+
+	if json.Unmarshal([]byte("[]"), &list) != ErrNoFactory {
+		t.Fail()
+	}
+
+	list.SetJSONOptions(JSONOptions{Factory: complexFactory})
+
+	// Order and duplicates are preserved, even if the input is not sorted.
+	if err := json.Unmarshal([]byte(`[{"E":3,"S":"x"},{"E":1,"S":"a"},{"E":3,"S":"y"},{"E":2,"S":"b"}]`), &list); err != nil {
+		t.Fatal(err)
+	}
+	checkStructure(t, &list)
+	var values []ComplexElement
+	for v := range list.All() {
+		values = append(values, v.(ComplexElement))
+	}
+	if len(values) != 4 || values[0].S != "a" || values[2].S != "x" || values[3].S != "y" {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	// A round trip with keys.
+	list.SetJSONOptions(JSONOptions{WithKeys: true, Factory: complexFactory})
+	data, err := json.Marshal(&list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := New()
+	decoded.SetJSONOptions(JSONOptions{WithKeys: true, Factory: complexFactory})
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() == "" || decoded.GetNodeCount() != 4 || decoded.CountKey(Element(3)) != 2 {
+		t.Fail()
+	}
+
+	// Invalid data does not change the list.
+	if json.Unmarshal([]byte(`[{"key":5,"value":{"E":3,"S":"x"}}]`), &decoded) != ErrInvalidFormat {
+		t.Fail()
+	}
+	if json.Unmarshal([]byte(`{}`), &decoded) == nil || decoded.GetNodeCount() != 4 {
+		t.Fail()
+	}
+}
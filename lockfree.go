@@ -0,0 +1,331 @@
+package skiplist
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// markableReference is an immutable pair of a next pointer and a deletion mark.
+// Both are always swapped together with one compare-and-swap on the atomic pointer to it.
+type markableReference struct {
+	node   *LockFreeSkipListElement
+	marked bool
+}
+
+// LockFreeSkipListElement represents one actual Node in the lock-free skiplist structure.
+// It saves the actual element and the marked pointers to the next nodes.
+// There is no pointer to a previous node, as that can not be kept consistent without locking.
+type LockFreeSkipListElement struct {
+	next  [maxLevel]atomic.Pointer[markableReference]
+	level int
+	key   float64
+	value ListElement
+}
+
+// LockFreeSkipList is a lock-free skiplist (Herlihy/Shavit, Fraser) that supports concurrent
+// Insert, Delete and Find from many goroutines without any locks.
+// A node is deleted logically by marking its next pointers and unlinked physically by the next
+// search that passes it. Unlike SkipList, the keys are unique, a second Insert with an equal key fails.
+type LockFreeSkipList struct {
+	head         *LockFreeSkipListElement
+	elementCount atomic.Int64
+	eps          float64
+	randomState  atomic.Uint64
+}
+
+// NewLockFreeSeedEps returns a new empty, initialized lock-free Skiplist.
+// Given a seed, the heights are generated independent of any other list. As the goroutines race for the
+// random numbers, the heights are only deterministic for a single goroutine.
+// Eps is used to compare keys given by the ExtractKey() function on equality.
+func NewLockFreeSeedEps(seed int64, eps float64) *LockFreeSkipList {
+	head := &LockFreeSkipListElement{
+		level: maxLevel - 1,
+	}
+	for i := range head.next {
+		head.next[i].Store(&markableReference{})
+	}
+	list := &LockFreeSkipList{
+		head: head,
+		eps:  eps,
+	}
+	list.randomState.Store(uint64(seed))
+	return list
+}
+
+// NewLockFreeEps returns a new empty, initialized lock-free Skiplist.
+// Eps is used to compare keys given by the ExtractKey() function on equality.
+func NewLockFreeEps(eps float64) *LockFreeSkipList {
+	return NewLockFreeSeedEps(time.Now().UTC().UnixNano(), eps)
+}
+
+// NewLockFree returns a new empty, initialized lock-free Skiplist.
+func NewLockFree() *LockFreeSkipList {
+	return NewLockFreeEps(eps)
+}
+
+// random returns the next number of the SplitMix64 sequence of the list.
+// It only needs one atomic addition, so it is safe for concurrent use without locking.
+func (t *LockFreeSkipList) random() uint64 {
+	z := t.randomState.Add(0x9e3779b97f4a7c15)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// casNext atomically replaces the next pointer on the given level, if it still equals the expected pointer and mark.
+func (e *LockFreeSkipListElement) casNext(level int, expected *LockFreeSkipListElement, expectedMark bool, newNode *LockFreeSkipListElement, newMark bool) bool {
+	ref := e.next[level].Load()
+	if ref.node != expected || ref.marked != expectedMark {
+		return false
+	}
+	if ref.node == newNode && ref.marked == newMark {
+		return true
+	}
+	return e.next[level].CompareAndSwap(ref, &markableReference{newNode, newMark})
+}
+
+// GetValue extracts the ListElement value from a skiplist node.
+func (e *LockFreeSkipListElement) GetValue() ListElement {
+	return e.value
+}
+
+// IsEmpty checks, if the skiplist is empty.
+func (t *LockFreeSkipList) IsEmpty() bool {
+	return t.GetSmallestNode() == nil
+}
+
+// GetNodeCount returns the number of nodes currently in the skiplist.
+func (t *LockFreeSkipList) GetNodeCount() int {
+	return int(t.elementCount.Load())
+}
+
+func (t *LockFreeSkipList) generateLevel(maxLevel int) int {
+	level := maxLevel - 1
+	var x uint64 = t.random() & ((1 << uint(maxLevel-1)) - 1)
+	zeroes := bits.TrailingZeros64(x)
+	if zeroes < maxLevel {
+		level = zeroes
+	}
+
+	return level
+}
+
+// less checks, if a is smaller than the key and not equal to it.
+func (t *LockFreeSkipList) less(a, key float64) bool {
+	return key-a > t.eps
+}
+
+func (t *LockFreeSkipList) equal(a, key float64) bool {
+	return math.Abs(a-key) <= t.eps
+}
+
+// find fills preds and succs with the last node before and the first node not before the key on every level.
+// All marked nodes that are passed are unlinked on the way.
+func (t *LockFreeSkipList) find(key float64, preds, succs *[maxLevel]*LockFreeSkipListElement) bool {
+retry:
+	for {
+		pred := t.head
+		for level := maxLevel - 1; level >= 0; level-- {
+			curr := pred.next[level].Load().node
+			for curr != nil {
+				ref := curr.next[level].Load()
+				for ref.marked {
+					// Remove the logically deleted node from this level.
+					if !pred.casNext(level, curr, false, ref.node, false) {
+						continue retry
+					}
+					curr = ref.node
+					if curr == nil {
+						break
+					}
+					ref = curr.next[level].Load()
+				}
+				if curr == nil || !t.less(curr.key, key) {
+					break
+				}
+				// Go right
+				pred = curr
+				curr = ref.node
+			}
+			preds[level] = pred
+			succs[level] = curr
+		}
+		return succs[0] != nil && t.equal(succs[0].key, key)
+	}
+}
+
+// findGreaterOrEqual searches without modifying the skiplist and never retries.
+func (t *LockFreeSkipList) findGreaterOrEqual(key float64) *LockFreeSkipListElement {
+	pred := t.head
+	var curr *LockFreeSkipListElement
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr = pred.next[level].Load().node
+		for curr != nil {
+			ref := curr.next[level].Load()
+			// Skip all logically deleted nodes.
+			for ref.marked {
+				curr = ref.node
+				if curr == nil {
+					break
+				}
+				ref = curr.next[level].Load()
+			}
+			if curr == nil || !t.less(curr.key, key) {
+				break
+			}
+			// Go right
+			pred = curr
+			curr = ref.node
+		}
+	}
+	return curr
+}
+
+// Insert inserts the given ListElement into the skiplist.
+// ok is false, if there already is an element with an equal key.
+// Insert is safe for concurrent use and runs in approx. O(log(n))
+func (t *LockFreeSkipList) Insert(e ListElement) (ok bool) {
+
+	if t == nil || e == nil {
+		return
+	}
+
+	key := e.ExtractKey()
+	level := t.generateLevel(maxLevel)
+
+	var preds, succs [maxLevel]*LockFreeSkipListElement
+
+	for {
+		if t.find(key, &preds, &succs) {
+			return false
+		}
+
+		elem := &LockFreeSkipListElement{
+			level: level,
+			key:   key,
+			value: e,
+		}
+		for i := 0; i <= level; i++ {
+			elem.next[i].Store(&markableReference{node: succs[i]})
+		}
+
+		// Linking the bottom level makes the element part of the list.
+		if !preds[0].casNext(0, succs[0], false, elem, false) {
+			continue
+		}
+		t.elementCount.Add(1)
+
+		for i := 1; i <= level; i++ {
+			for {
+				ref := elem.next[i].Load()
+				if ref.marked {
+					// The element is already being deleted, there is no need to link it any further.
+					return true
+				}
+				if ref.node != succs[i] && !elem.next[i].CompareAndSwap(ref, &markableReference{node: succs[i]}) {
+					continue
+				}
+				if preds[i].casNext(i, succs[i], false, elem, false) {
+					break
+				}
+				t.find(key, &preds, &succs)
+				if succs[0] != elem {
+					return true
+				}
+			}
+		}
+		return true
+	}
+}
+
+// Delete removes the element equal to e from the skiplist, if there is one.
+// ok is true, if this call removed the element.
+// Delete is safe for concurrent use and runs in approx. O(log(n))
+func (t *LockFreeSkipList) Delete(e ListElement) (ok bool) {
+
+	if t == nil || e == nil {
+		return
+	}
+
+	key := e.ExtractKey()
+
+	var preds, succs [maxLevel]*LockFreeSkipListElement
+
+	if !t.find(key, &preds, &succs) {
+		return false
+	}
+	elem := succs[0]
+
+	// Mark all upper levels first, so the element is not reachable from above anymore.
+	for i := elem.level; i >= 1; i-- {
+		ref := elem.next[i].Load()
+		for !ref.marked {
+			elem.next[i].CompareAndSwap(ref, &markableReference{ref.node, true})
+			ref = elem.next[i].Load()
+		}
+	}
+
+	// Whoever marks the bottom level, actually deleted the element.
+	ref := elem.next[0].Load()
+	for !ref.marked {
+		if elem.next[0].CompareAndSwap(ref, &markableReference{ref.node, true}) {
+			t.elementCount.Add(-1)
+			// Physically unlink the element.
+			t.find(key, &preds, &succs)
+			return true
+		}
+		ref = elem.next[0].Load()
+	}
+	return false
+}
+
+// Find tries to find an element in the skiplist based on the key from the given ListElement.
+// elem can be used, if ok is true.
+// Find is wait-free and runs in approx. O(log(n))
+func (t *LockFreeSkipList) Find(e ListElement) (elem *LockFreeSkipListElement, ok bool) {
+
+	if t == nil || e == nil {
+		return
+	}
+
+	key := e.ExtractKey()
+	if node := t.findGreaterOrEqual(key); node != nil && t.equal(node.key, key) {
+		elem, ok = node, true
+	}
+	return
+}
+
+// FindGreaterOrEqual finds the first element, that is greater or equal to the given ListElement e.
+// The comparison is done on the keys (So on ExtractKey()).
+// FindGreaterOrEqual is wait-free and runs in approx. O(log(n))
+func (t *LockFreeSkipList) FindGreaterOrEqual(e ListElement) (elem *LockFreeSkipListElement, ok bool) {
+
+	if t == nil || e == nil {
+		return
+	}
+
+	elem = t.findGreaterOrEqual(e.ExtractKey())
+	ok = elem != nil
+	return
+}
+
+// GetSmallestNode returns the very first/smallest node in the skiplist that is not deleted.
+func (t *LockFreeSkipList) GetSmallestNode() *LockFreeSkipListElement {
+	return t.Next(t.head)
+}
+
+// Next returns the next element based on the given node, that is not deleted.
+// Unlike SkipList.Next, Next does not loop around and returns nil after the last node.
+func (t *LockFreeSkipList) Next(e *LockFreeSkipListElement) *LockFreeSkipListElement {
+	node := e.next[0].Load().node
+	for node != nil {
+		ref := node.next[0].Load()
+		if !ref.marked {
+			return node
+		}
+		node = ref.node
+	}
+	return nil
+}
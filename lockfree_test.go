@@ -0,0 +1,211 @@
+package skiplist
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLockFreeInsertAndFind(t *testing.T) {
+	var listPointer *LockFreeSkipList
+	listPointer.Insert(Element(0))
+	if _, ok := listPointer.Find(Element(0)); ok {
+		t.Fail()
+	}
+
+	list := NewLockFree()
+	if !list.IsEmpty() {
+		t.Fail()
+	}
+
+	rList := rand.Perm(maxN / 10)
+	for _, e := range rList {
+		if !list.Insert(Element(e)) {
+			t.Fail()
+		}
+	}
+	// Keys are unique.
+	if list.Insert(Element(rList[0])) {
+		t.Fail()
+	}
+	for _, e := range rList {
+		if v, ok := list.Find(Element(e)); !ok || v.GetValue().(Element) != Element(e) {
+			t.Fail()
+		}
+	}
+	if list.GetNodeCount() != len(rList) {
+		t.Fail()
+	}
+
+	if v, ok := list.FindGreaterOrEqual(FloatElement(10.5)); !ok || v.GetValue().(Element) != 11 {
+		t.Fail()
+	}
+
+	for _, e := range rList {
+		if !list.Delete(Element(e)) {
+			t.Fail()
+		}
+		if list.Delete(Element(e)) {
+			t.Fail()
+		}
+	}
+	if !list.IsEmpty() || list.GetNodeCount() != 0 {
+		t.Fail()
+	}
+}
+
+// compareLockFree checks, that the lock-free skiplist contains exactly the same keys as the sequential one.
+func compareLockFree(t *testing.T, list *LockFreeSkipList, expected *SkipList) {
+	if list.GetNodeCount() != expected.GetNodeCount() {
+		t.Errorf("node count %v, expected %v", list.GetNodeCount(), expected.GetNodeCount())
+	}
+	node := list.GetSmallestNode()
+	expectedNode := expected.GetSmallestNode()
+	for i := 0; i < expected.GetNodeCount(); i++ {
+		if node == nil || node.key != expectedNode.key {
+			t.Errorf("lock-free skiplist differs at position %v", i)
+			return
+		}
+		node = list.Next(node)
+		expectedNode = expectedNode.next[0]
+	}
+	if node != nil {
+		t.Errorf("lock-free skiplist has too many elements")
+	}
+}
+
+func TestLockFreeStress(t *testing.T) {
+	list := NewLockFree()
+	expected := New()
+
+	const workers = 8
+	const n = 20000
+
+	for i := 0; i < n; i++ {
+		if i%3 != 0 {
+			expected.Insert(Element(i))
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for _, i := range rand.Perm(n / workers) {
+				key := i*workers + w
+				list.Insert(Element(key))
+				if key%3 == 0 {
+					if !list.Delete(Element(key)) {
+						t.Fail()
+					}
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if v, ok := list.FindGreaterOrEqual(Element(rand.Intn(n))); ok && v.GetValue() == nil {
+					t.Fail()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	compareLockFree(t, list, &expected)
+}
+
+func TestLockFreeContention(t *testing.T) {
+	list := NewLockFree()
+
+	const workers = 8
+	const n = 5000
+
+	// All goroutines fight over the same keys. Every key must be inserted and deleted exactly once.
+	var inserted, deleted atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if list.Insert(Element(i)) {
+					inserted.Add(1)
+				}
+			}
+			for i := 0; i < n; i++ {
+				if list.Delete(Element(i)) {
+					deleted.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if inserted.Load() != deleted.Load() || inserted.Load() < n {
+		t.Fail()
+	}
+	if !list.IsEmpty() || list.GetNodeCount() != 0 {
+		t.Fail()
+	}
+
+	// Concurrent inserts of the same keys only succeed once.
+	inserted.Store(0)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if list.Insert(Element(i)) {
+					inserted.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	expected := New()
+	for i := 0; i < n; i++ {
+		expected.Insert(Element(i))
+	}
+	if inserted.Load() != n {
+		t.Fail()
+	}
+	compareLockFree(t, list, &expected)
+}
+
+func TestLockFreeSeed(t *testing.T) {
+	heights := func(seed int64) []int {
+		list := NewLockFreeSeedEps(seed, eps)
+		for i := 0; i < 1000; i++ {
+			list.Insert(Element(i))
+		}
+		var levels []int
+		for node := list.GetSmallestNode(); node != nil; node = list.Next(node) {
+			levels = append(levels, node.level)
+		}
+		return levels
+	}
+
+	// The same seed gives the same heights.
+	a := heights(1)
+	b := heights(1)
+	c := heights(2)
+	if !slices.Equal(a, b) || slices.Equal(a, c) {
+		t.Fail()
+	}
+
+	// Roughly half of all nodes are promoted.
+	promoted := 0
+	for _, level := range a {
+		if level > 0 {
+			promoted++
+		}
+	}
+	if promoted < 400 || promoted > 600 {
+		t.Errorf("%v of 1000 nodes are promoted", promoted)
+	}
+}
@@ -0,0 +1,165 @@
+// Package memtable implements the in-memory write buffer of a log-structured merge tree on top of a skiplist,
+// and immutable sorted tables on disk, that a memtable is flushed to.
+//
+// Keys and values are byte slices. Every Put and Delete gets a sequence number, so newer versions of a key
+// shadow older ones. A Delete writes a tombstone, that shadows older versions in other tables.
+package memtable
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/MauriceGit/skiplist"
+)
+
+// entryOverhead approximates the memory of one entry in addition to its key and value.
+const entryOverhead = 64
+
+// Entry is one version of a key.
+type Entry struct {
+	Key      []byte
+	Value    []byte
+	Sequence uint64
+	// Deleted marks a tombstone, that shadows all older versions of the key.
+	Deleted bool
+}
+
+// internalKey orders all versions of a key from newest to oldest.
+type internalKey struct {
+	key      []byte
+	sequence uint64
+}
+
+func compareInternalKeys(a, b internalKey) int {
+	if c := bytes.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	switch {
+	case a.sequence > b.sequence:
+		return -1
+	case a.sequence < b.sequence:
+		return 1
+	}
+	return 0
+}
+
+// record is the part of an entry, that is saved as the skiplist value.
+type record struct {
+	data    []byte
+	deleted bool
+}
+
+// Memtable buffers writes in sorted order until it is flushed to a table.
+// Memtable is not safe for concurrent use.
+type Memtable struct {
+	list      skiplist.GenericSkipList[internalKey, record]
+	sequence  uint64
+	size      int
+	threshold int
+}
+
+// New returns a new empty memtable, that should be flushed after approx. threshold bytes.
+// The first write gets the sequence number 1.
+func New(threshold int) *Memtable {
+	return NewWithSequence(threshold, 0)
+}
+
+// NewWithSequence returns a new empty memtable like New, whose first write gets the sequence number sequence+1.
+// It is used to replace a flushed memtable, so newer versions still shadow the ones in older tables.
+func NewWithSequence(threshold int, sequence uint64) *Memtable {
+	return &Memtable{
+		list:      skiplist.NewGenericFunc[internalKey, record](compareInternalKeys),
+		sequence:  sequence,
+		threshold: threshold,
+	}
+}
+
+// add inserts a new version of key and returns its sequence number.
+// key and data are copied, so the caller can reuse them.
+func (m *Memtable) add(key, data []byte, deleted bool) uint64 {
+	m.sequence++
+	m.list.Insert(internalKey{bytes.Clone(key), m.sequence}, record{bytes.Clone(data), deleted})
+	m.size += len(key) + len(data) + entryOverhead
+	return m.sequence
+}
+
+// Put sets the value of key and returns the sequence number of the new version.
+// Put runs in approx. O(log(n))
+func (m *Memtable) Put(key, value []byte) (sequence uint64) {
+	return m.add(key, value, false)
+}
+
+// Delete writes a tombstone for key and returns its sequence number.
+// Delete runs in approx. O(log(n))
+func (m *Memtable) Delete(key []byte) (sequence uint64) {
+	return m.add(key, nil, true)
+}
+
+// Get returns the newest version of key. entry can be used, if ok is true.
+// A deleted key returns its tombstone, so the caller knows not to look in older tables.
+// Get runs in approx. O(log(n))
+func (m *Memtable) Get(key []byte) (entry Entry, ok bool) {
+	node, found := m.list.FindGreaterOrEqual(internalKey{key, math.MaxUint64})
+	if found && node != nil && bytes.Equal(node.GetKey().key, key) {
+		return newEntry(node.GetKey(), node.GetValue()), true
+	}
+	return
+}
+
+// Scan calls f for the newest version of every key in [start, end) in increasing order, until f returns false.
+// Tombstones are included. A nil start or end is unbounded.
+func (m *Memtable) Scan(start, end []byte, f func(entry Entry) bool) {
+	var last []byte
+	seen := false
+	for k, v := range m.list.From(internalKey{start, math.MaxUint64}) {
+		if end != nil && bytes.Compare(k.key, end) >= 0 {
+			return
+		}
+		// Older versions follow the newest one. A nil key is a valid (empty) key, so it can not mark the start.
+		if seen && bytes.Equal(k.key, last) {
+			continue
+		}
+		last, seen = k.key, true
+		if !f(newEntry(k, v)) {
+			return
+		}
+	}
+}
+
+func newEntry(k internalKey, r record) Entry {
+	return Entry{Key: k.key, Value: r.data, Sequence: k.sequence, Deleted: r.deleted}
+}
+
+// Sequence returns the sequence number of the last write, or the starting sequence number, if there was none.
+func (m *Memtable) Sequence() uint64 {
+	return m.sequence
+}
+
+// Size returns the approx. memory used by all entries in bytes.
+func (m *Memtable) Size() int {
+	return m.size
+}
+
+// Len returns the number of entries including older versions and tombstones.
+func (m *Memtable) Len() int {
+	return m.list.GetNodeCount()
+}
+
+// ShouldFlush checks, if the memtable reached its size threshold.
+func (m *Memtable) ShouldFlush() bool {
+	return m.size >= m.threshold
+}
+
+// Flush writes the newest version of every key including tombstones to a table file at path.
+// The file is written to a temporary file first and renamed afterwards.
+// The memtable is not changed, it should be replaced with a new one after the flush.
+func (m *Memtable) Flush(path string, options TableOptions) error {
+	return writeTableFile(path, options, func(w *TableWriter) error {
+		var err error
+		m.Scan(nil, nil, func(entry Entry) bool {
+			err = w.Add(entry)
+			return err == nil
+		})
+		return err
+	})
+}
@@ -0,0 +1,165 @@
+package memtable
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func key(i int) []byte {
+	return []byte(fmt.Sprintf("key%05d", i))
+}
+
+func TestMemtable(t *testing.T) {
+	m := New(1000)
+
+	if _, ok := m.Get(key(1)); ok {
+		t.Fail()
+	}
+
+	buf := []byte("value")
+	if m.Put(key(1), buf) != 1 || m.Put(key(2), []byte("b")) != 2 {
+		t.Fail()
+	}
+	// Put copies the value.
+	buf[0] = 'x'
+	if e, ok := m.Get(key(1)); !ok || string(e.Value) != "value" || e.Sequence != 1 || e.Deleted {
+		t.Fail()
+	}
+
+	// Newer versions shadow older ones.
+	m.Put(key(1), []byte("new"))
+	if e, ok := m.Get(key(1)); !ok || string(e.Value) != "new" || e.Sequence != 3 {
+		t.Fail()
+	}
+	m.Delete(key(2))
+	if e, ok := m.Get(key(2)); !ok || !e.Deleted || e.Sequence != 4 {
+		t.Fail()
+	}
+	if m.Len() != 4 {
+		t.Fail()
+	}
+
+	if m.ShouldFlush() {
+		t.Fail()
+	}
+	for i := 0; !m.ShouldFlush(); i++ {
+		m.Put(key(i), []byte("value"))
+	}
+	if m.Size() < 1000 {
+		t.Fail()
+	}
+}
+
+func TestMemtableScan(t *testing.T) {
+	m := New(1 << 20)
+	for i := 0; i < 100; i++ {
+		m.Put(key(i), []byte("old"))
+	}
+	for i := 0; i < 100; i += 2 {
+		m.Put(key(i), []byte("new"))
+	}
+	m.Delete(key(50))
+
+	var entries []Entry
+	m.Scan(key(40), key(60), func(e Entry) bool {
+		entries = append(entries, e)
+		return true
+	})
+	if len(entries) != 20 {
+		t.Fatalf("expected 20 entries, got %v", len(entries))
+	}
+	for i, e := range entries {
+		expected := "old"
+		if i%2 == 0 {
+			expected = "new"
+		}
+		if !bytes.Equal(e.Key, key(40+i)) || string(e.Value) != expected && !e.Deleted {
+			t.Errorf("unexpected entry %v", e)
+		}
+	}
+	if !entries[10].Deleted {
+		t.Fail()
+	}
+
+	count := 0
+	m.Scan(nil, nil, func(e Entry) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Fail()
+	}
+}
+
+func TestMemtableEmptyKey(t *testing.T) {
+	m := New(1 << 20)
+	m.Put([]byte{}, []byte("a"))
+	m.Put(nil, []byte("b"))
+	m.Put(nil, []byte("c"))
+	m.Put(key(1), []byte("d"))
+
+	// nil and empty keys are the same key.
+	if e, ok := m.Get(nil); !ok || string(e.Value) != "c" {
+		t.Fail()
+	}
+	var entries []Entry
+	m.Scan(nil, nil, func(e Entry) bool {
+		entries = append(entries, e)
+		return true
+	})
+	if len(entries) != 2 || string(entries[0].Value) != "c" || len(entries[0].Key) != 0 {
+		t.Fatalf("unexpected entries %v", entries)
+	}
+
+	path := filepath.Join(t.TempDir(), "000001.sst")
+	if err := m.Flush(path, TableOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	table, err := OpenTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Close()
+	if e, ok, err := table.Get([]byte{}); !ok || err != nil || string(e.Value) != "c" || table.Len() != 2 {
+		t.Fail()
+	}
+}
+
+func TestMemtableSequence(t *testing.T) {
+	dir := t.TempDir()
+	m := New(1 << 20)
+	if m.Sequence() != 0 {
+		t.Fail()
+	}
+	m.Put(key(1), []byte("old"))
+	m.Delete(key(2))
+	if m.Sequence() != 2 {
+		t.Fail()
+	}
+	if err := m.Flush(filepath.Join(dir, "000001.sst"), TableOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	table, err := OpenTable(filepath.Join(dir, "000001.sst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Close()
+
+	// The next memtable continues the sequence, so its versions are newer than the flushed ones.
+	next := NewWithSequence(1<<20, m.Sequence())
+	if next.Put(key(2), []byte("new")) != 3 || next.Put(key(1), []byte("new")) != 4 {
+		t.Fail()
+	}
+	for i := 1; i <= 2; i++ {
+		old, ok, err := table.Get(key(i))
+		if !ok || err != nil {
+			t.Fatal(err)
+		}
+		e, _ := next.Get(key(i))
+		if e.Sequence <= old.Sequence || string(e.Value) != "new" {
+			t.Errorf("%v does not shadow %v", e, old)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package memtable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	tableMagic = 0x31545350_4c504b53 // "SKPLPST1"
+	footerSize = 32
+	// DefaultBlockSize is the block size, if TableOptions.BlockSize is not set.
+	DefaultBlockSize = 4096
+)
+
+var (
+	// ErrNotSorted is returned, if entries are not added to a table in strictly increasing key order.
+	ErrNotSorted = errors.New("memtable: keys are not strictly increasing")
+	// ErrCorrupted is returned, if a table is truncated or a checksum does not match.
+	ErrCorrupted = errors.New("memtable: table is truncated or corrupted")
+)
+
+// TableOptions configures the layout of a table.
+type TableOptions struct {
+	// BlockSize is the size in bytes, after which a data block is written.
+	BlockSize int
+}
+
+// blockHandle locates one data block in the table file.
+type blockHandle struct {
+	lastKey []byte
+	offset  uint64
+	size    uint64
+}
+
+// TableWriter writes an immutable sorted table.
+//
+// A table consists of data blocks with the entries, an index block with the last key, offset and size of every
+// data block and a fixed size footer, that locates the index. Every block is followed by its CRC32 checksum.
+type TableWriter struct {
+	w       io.Writer
+	options TableOptions
+	block   []byte
+	index   []blockHandle
+	lastKey []byte
+	offset  uint64
+	count   uint64
+}
+
+// NewTableWriter returns a writer for a new table, that is written to w.
+func NewTableWriter(w io.Writer, options TableOptions) *TableWriter {
+	if options.BlockSize <= 0 {
+		options.BlockSize = DefaultBlockSize
+	}
+	return &TableWriter{
+		w:       w,
+		options: options,
+	}
+}
+
+// Add appends an entry to the table. The keys must be strictly increasing.
+func (t *TableWriter) Add(entry Entry) error {
+
+	if t.count > 0 && bytes.Compare(entry.Key, t.lastKey) <= 0 {
+		return ErrNotSorted
+	}
+
+	t.block = appendEntry(t.block, entry)
+	t.lastKey = append(t.lastKey[:0], entry.Key...)
+	t.count++
+
+	if len(t.block) >= t.options.BlockSize {
+		return t.flushBlock()
+	}
+	return nil
+}
+
+func appendEntry(buf []byte, entry Entry) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(entry.Key)))
+	buf = append(buf, entry.Key...)
+	buf = binary.AppendUvarint(buf, entry.Sequence)
+	if entry.Deleted {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(entry.Value)))
+	return append(buf, entry.Value...)
+}
+
+// writeBlock writes the block with its checksum and returns its handle.
+func (t *TableWriter) writeBlock(block []byte) (blockHandle, error) {
+	handle := blockHandle{offset: t.offset, size: uint64(len(block))}
+	block = binary.LittleEndian.AppendUint32(block, crc32.ChecksumIEEE(block))
+	if _, err := t.w.Write(block); err != nil {
+		return handle, err
+	}
+	t.offset += uint64(len(block))
+	return handle, nil
+}
+
+func (t *TableWriter) flushBlock() error {
+	if len(t.block) == 0 {
+		return nil
+	}
+	handle, err := t.writeBlock(t.block)
+	if err != nil {
+		return err
+	}
+	handle.lastKey = bytes.Clone(t.lastKey)
+	t.index = append(t.index, handle)
+	t.block = t.block[:0]
+	return nil
+}
+
+// Finish writes the last data block, the index and the footer. The writer must not be used afterwards.
+func (t *TableWriter) Finish() error {
+
+	if err := t.flushBlock(); err != nil {
+		return err
+	}
+
+	var index []byte
+	for _, handle := range t.index {
+		index = binary.AppendUvarint(index, uint64(len(handle.lastKey)))
+		index = append(index, handle.lastKey...)
+		index = binary.AppendUvarint(index, handle.offset)
+		index = binary.AppendUvarint(index, handle.size)
+	}
+	handle, err := t.writeBlock(index)
+	if err != nil {
+		return err
+	}
+
+	footer := make([]byte, 0, footerSize)
+	footer = binary.LittleEndian.AppendUint64(footer, handle.offset)
+	footer = binary.LittleEndian.AppendUint64(footer, handle.size)
+	footer = binary.LittleEndian.AppendUint64(footer, t.count)
+	footer = binary.LittleEndian.AppendUint64(footer, tableMagic)
+	_, err = t.w.Write(footer)
+	return err
+}
+
+// writeTableFile writes a table with the entries added by fill to a temporary file and renames it to path.
+func writeTableFile(path string, options TableOptions, fill func(w *TableWriter) error) (err error) {
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	bw := bufio.NewWriter(file)
+	w := NewTableWriter(bw, options)
+	if err = fill(w); err != nil {
+		return err
+	}
+	if err = w.Finish(); err != nil {
+		return err
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Table is an immutable sorted table on disk. Only the index is kept in memory,
+// the data blocks are read on demand. Table is safe for concurrent use.
+type Table struct {
+	file  *os.File
+	index []blockHandle
+	count uint64
+}
+
+// OpenTable opens the table at path and reads its index.
+func OpenTable(path string) (*Table, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{file: file}
+	if err := t.readIndex(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+func (t *Table) readIndex() error {
+
+	info, err := t.file.Stat()
+	if err != nil {
+		return err
+	}
+	// The smallest table has an empty index block with its checksum.
+	if info.Size() < footerSize+4 {
+		return ErrCorrupted
+	}
+
+	footer := make([]byte, footerSize)
+	if _, err := t.file.ReadAt(footer, info.Size()-footerSize); err != nil {
+		return err
+	}
+	if binary.LittleEndian.Uint64(footer[24:]) != tableMagic {
+		return ErrCorrupted
+	}
+	offset := binary.LittleEndian.Uint64(footer)
+	size := binary.LittleEndian.Uint64(footer[8:])
+	t.count = binary.LittleEndian.Uint64(footer[16:])
+	// offset and size are checked separately, so a corrupted footer can not overflow the sum.
+	limit := uint64(info.Size()) - footerSize - 4
+	if offset > limit || size != limit-offset {
+		return ErrCorrupted
+	}
+
+	index, err := t.readBlock(blockHandle{offset: offset, size: size})
+	if err != nil {
+		return err
+	}
+
+	var end uint64
+	for len(index) > 0 {
+		var handle blockHandle
+		var ok bool
+		if handle.lastKey, index, ok = readBytes(index); !ok {
+			return ErrCorrupted
+		}
+		if handle.offset, index, ok = readUvarint(index); !ok {
+			return ErrCorrupted
+		}
+		if handle.size, index, ok = readUvarint(index); !ok {
+			return ErrCorrupted
+		}
+		// The data blocks are written back to back before the index.
+		if handle.offset != end || offset-end < 4 || handle.size > offset-end-4 {
+			return ErrCorrupted
+		}
+		end = handle.offset + handle.size + 4
+		t.index = append(t.index, handle)
+	}
+	if end != offset {
+		return ErrCorrupted
+	}
+	return nil
+}
+
+// readBlock reads a block and verifies its checksum.
+func (t *Table) readBlock(handle blockHandle) ([]byte, error) {
+	block := make([]byte, handle.size+4)
+	if _, err := t.file.ReadAt(block, int64(handle.offset)); err != nil {
+		if err == io.EOF {
+			err = ErrCorrupted
+		}
+		return nil, err
+	}
+	data := block[:handle.size]
+	if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(block[handle.size:]) {
+		return nil, ErrCorrupted
+	}
+	return data, nil
+}
+
+func readUvarint(data []byte) (uint64, []byte, bool) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, false
+	}
+	return v, data[n:], true
+}
+
+func readBytes(data []byte) ([]byte, []byte, bool) {
+	length, data, ok := readUvarint(data)
+	if !ok || length > uint64(len(data)) {
+		return nil, nil, false
+	}
+	return data[:length], data[length:], true
+}
+
+// readEntry decodes one entry from the start of data and returns the remaining data.
+func readEntry(data []byte) (entry Entry, rest []byte, err error) {
+	var ok bool
+	if entry.Key, data, ok = readBytes(data); !ok {
+		return entry, nil, ErrCorrupted
+	}
+	if entry.Sequence, data, ok = readUvarint(data); !ok || len(data) == 0 {
+		return entry, nil, ErrCorrupted
+	}
+	entry.Deleted = data[0] == 1
+	if entry.Value, data, ok = readBytes(data[1:]); !ok {
+		return entry, nil, ErrCorrupted
+	}
+	return entry, data, nil
+}
+
+// Len returns the number of entries in the table.
+func (t *Table) Len() int {
+	return int(t.count)
+}
+
+// Get returns the entry for key. entry can be used, if ok is true.
+// A deleted key returns its tombstone. Get reads at most one data block.
+func (t *Table) Get(key []byte) (entry Entry, ok bool, err error) {
+	err = t.Scan(key, nil, func(e Entry) bool {
+		entry, ok = e, bytes.Equal(e.Key, key)
+		return false
+	})
+	return
+}
+
+// Scan calls f for every entry with a key in [start, end) in increasing order, until f returns false.
+// Tombstones are included. A nil start or end is unbounded.
+func (t *Table) Scan(start, end []byte, f func(entry Entry) bool) error {
+
+	// The first block, that might contain start.
+	first := sort.Search(len(t.index), func(i int) bool {
+		return bytes.Compare(t.index[i].lastKey, start) >= 0
+	})
+
+	for _, handle := range t.index[first:] {
+		block, err := t.readBlock(handle)
+		if err != nil {
+			return err
+		}
+		for len(block) > 0 {
+			var entry Entry
+			if entry, block, err = readEntry(block); err != nil {
+				return err
+			}
+			if bytes.Compare(entry.Key, start) < 0 {
+				continue
+			}
+			if end != nil && bytes.Compare(entry.Key, end) >= 0 {
+				return nil
+			}
+			if !f(entry) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the table file.
+func (t *Table) Close() error {
+	return t.file.Close()
+}
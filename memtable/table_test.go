@@ -0,0 +1,151 @@
+package memtable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "000001.sst")
+
+	m := New(1 << 20)
+	for i := 0; i < 1000; i++ {
+		m.Put(key(i), bytes.Repeat([]byte{'v'}, i%50))
+	}
+	m.Delete(key(500))
+	m.Put(key(7), []byte("new"))
+
+	if err := m.Flush(path, TableOptions{BlockSize: 512}); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := OpenTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Close()
+
+	if table.Len() != 1000 || len(table.index) < 10 {
+		t.Fail()
+	}
+
+	for i := 0; i < 1000; i++ {
+		e, ok, err := table.Get(key(i))
+		if err != nil || !ok {
+			t.Fatalf("key %v not found", i)
+		}
+		switch i {
+		case 7:
+			if string(e.Value) != "new" {
+				t.Fail()
+			}
+		case 500:
+			if !e.Deleted {
+				t.Fail()
+			}
+		default:
+			if len(e.Value) != i%50 || e.Deleted {
+				t.Fail()
+			}
+		}
+	}
+	if _, ok, err := table.Get([]byte("key")); ok || err != nil {
+		t.Fail()
+	}
+	if _, ok, err := table.Get([]byte("zzz")); ok || err != nil {
+		t.Fail()
+	}
+
+	// The table contains the same entries as the memtable.
+	var expected, entries []Entry
+	m.Scan(key(100), key(300), func(e Entry) bool {
+		expected = append(expected, e)
+		return true
+	})
+	err = table.Scan(key(100), key(300), func(e Entry) bool {
+		entries = append(entries, e)
+		return true
+	})
+	if err != nil || len(entries) != 200 || len(entries) != len(expected) {
+		t.Fatalf("unexpected number of entries %v", len(entries))
+	}
+	for i := range entries {
+		if !bytes.Equal(entries[i].Key, expected[i].Key) || !bytes.Equal(entries[i].Value, expected[i].Value) ||
+			entries[i].Sequence != expected[i].Sequence {
+			t.Errorf("unexpected entry %v", entries[i])
+		}
+	}
+}
+
+func TestTableWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewTableWriter(&buf, TableOptions{})
+	if w.Add(Entry{Key: key(2)}) != nil || w.Add(Entry{Key: key(2)}) != ErrNotSorted || w.Add(Entry{Key: key(1)}) != ErrNotSorted {
+		t.Fail()
+	}
+
+	// An empty table.
+	path := filepath.Join(t.TempDir(), "empty.sst")
+	if err := New(0).Flush(path, TableOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	table, err := OpenTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := table.Get(key(1)); ok || err != nil || table.Len() != 0 {
+		t.Fail()
+	}
+	table.Close()
+}
+
+func TestTableCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "000001.sst")
+
+	m := New(1 << 20)
+	for i := 0; i < 1000; i++ {
+		m.Put(key(i), []byte("value"))
+	}
+	if err := m.Flush(path, TableOptions{BlockSize: 512}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+
+	// A truncated table can not be opened.
+	for _, size := range []int{0, 10, len(data) / 2, len(data) - 1} {
+		os.WriteFile(path, data[:size], 0644)
+		if _, err := OpenTable(path); !errors.Is(err, ErrCorrupted) {
+			t.Errorf("expected ErrCorrupted, got %v", err)
+		}
+	}
+
+	// A corrupted footer, whose offset and size overflow to the file size.
+	corrupted := append([]byte(nil), data...)
+	footer := corrupted[len(corrupted)-footerSize:]
+	binary.LittleEndian.PutUint64(footer, 1<<63)
+	binary.LittleEndian.PutUint64(footer[8:], 1<<63+uint64(len(data))-footerSize-4)
+	os.WriteFile(path, corrupted, 0644)
+	if _, err := OpenTable(path); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("expected ErrCorrupted, got %v", err)
+	}
+
+	// A corrupted data block is detected when it is read.
+	corrupted = append([]byte(nil), data...)
+	corrupted[100] ^= 0xff
+	os.WriteFile(path, corrupted, 0644)
+	table, err := OpenTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Close()
+	if _, _, err := table.Get(key(0)); err != ErrCorrupted {
+		t.Fail()
+	}
+	if _, _, err := table.Get(key(999)); err != nil {
+		t.Fail()
+	}
+}
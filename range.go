@@ -0,0 +1,57 @@
+package skiplist
+
+// RangeOptions configures the bounds and direction of Range.
+// The zero value includes both bounds and iterates in increasing order.
+type RangeOptions struct {
+	// ExcludeLow excludes elements equal to the lower bound.
+	ExcludeLow bool
+	// ExcludeHigh excludes elements equal to the upper bound.
+	ExcludeHigh bool
+	// Descending iterates from the upper to the lower bound.
+	Descending bool
+}
+
+// aboveLow checks, if the key of node is within the lower bound of a range.
+func (t *SkipList) aboveLow(node *SkipListElement, low float64, exclude bool) bool {
+	if exclude {
+		return node.key-low > t.eps
+	}
+	return low-node.key <= t.eps
+}
+
+// belowHigh checks, if the key of node is within the upper bound of a range.
+func (t *SkipList) belowHigh(node *SkipListElement, high float64, exclude bool) bool {
+	if exclude {
+		return high-node.key > t.eps
+	}
+	return node.key-high <= t.eps
+}
+
+// Range calls f for every element with a key between the keys of low and high, until f returns false.
+// opts configures, if elements equal to the bounds are included and the order of iteration.
+// Range does not loop around at the ends of the skiplist. f must not modify the skiplist!
+// Range runs in approx. O(log(n) + k) for k elements in the range.
+func (t *SkipList) Range(low, high ListElement, opts RangeOptions, f func(e *SkipListElement) bool) {
+
+	if t == nil || t.IsEmpty() || low == nil || high == nil {
+		return
+	}
+
+	lowKey := low.ExtractKey()
+	highKey := high.ExtractKey()
+
+	if opts.Descending {
+		for node := t.findLast(highKey, !opts.ExcludeHigh); node != nil && t.aboveLow(node, lowKey, opts.ExcludeLow); node = node.prev {
+			if !f(node) {
+				return
+			}
+		}
+		return
+	}
+
+	for node := t.nextNode(t.findLast(lowKey, opts.ExcludeLow), 0); node != nil && t.belowHigh(node, highKey, opts.ExcludeHigh); node = node.next[0] {
+		if !f(node) {
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package skiplist
+
+import (
+	"testing"
+)
+
+func collectRange(list *SkipList, low, high ListElement, opts RangeOptions) []Element {
+	var values []Element
+	list.Range(low, high, opts, func(e *SkipListElement) bool {
+		values = append(values, e.GetValue().(Element))
+		return true
+	})
+	return values
+}
+
+func equalElements(a, b []Element) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRange(t *testing.T) {
+	list := New()
+
+	if len(collectRange(&list, Element(0), Element(10), RangeOptions{})) != 0 {
+		t.Fail()
+	}
+
+	for i := 0; i < 100; i += 2 {
+		list.Insert(Element(i))
+	}
+
+	tests := []struct {
+		low, high ListElement
+		opts      RangeOptions
+		expected  []Element
+	}{
+		{Element(10), Element(16), RangeOptions{}, []Element{10, 12, 14, 16}},
+		{Element(10), Element(16), RangeOptions{ExcludeLow: true}, []Element{12, 14, 16}},
+		{Element(10), Element(16), RangeOptions{ExcludeHigh: true}, []Element{10, 12, 14}},
+		{Element(10), Element(16), RangeOptions{ExcludeLow: true, ExcludeHigh: true}, []Element{12, 14}},
+		{Element(10), Element(16), RangeOptions{Descending: true}, []Element{16, 14, 12, 10}},
+		{Element(10), Element(16), RangeOptions{Descending: true, ExcludeLow: true, ExcludeHigh: true}, []Element{14, 12}},
+		{FloatElement(9.5), FloatElement(14.5), RangeOptions{}, []Element{10, 12, 14}},
+		{FloatElement(9.5), FloatElement(14.5), RangeOptions{Descending: true}, []Element{14, 12, 10}},
+		{Element(-10), Element(3), RangeOptions{}, []Element{0, 2}},
+		{Element(95), Element(200), RangeOptions{}, []Element{96, 98}},
+		{Element(95), Element(200), RangeOptions{Descending: true}, []Element{98, 96}},
+		{Element(-10), Element(0), RangeOptions{Descending: true, ExcludeHigh: true}, nil},
+		{Element(98), Element(200), RangeOptions{ExcludeLow: true}, nil},
+		{Element(16), Element(10), RangeOptions{}, nil},
+		{Element(16), Element(10), RangeOptions{Descending: true}, nil},
+	}
+
+	for i, test := range tests {
+		if values := collectRange(&list, test.low, test.high, test.opts); !equalElements(values, test.expected) {
+			t.Errorf("range %v: got %v, expected %v", i, values, test.expected)
+		}
+	}
+
+	// Stop early.
+	count := 0
+	list.Range(Element(0), Element(100), RangeOptions{}, func(e *SkipListElement) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Fail()
+	}
+}
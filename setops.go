@@ -0,0 +1,132 @@
+package skiplist
+
+// mergeLists walks both skiplists in increasing order and collects the elements selected by the given functions.
+// onlyA and onlyB are called for elements without an equal element in the other list, both for a pair of equal elements.
+// Equal elements are paired in their insertion order.
+func (t *SkipList) mergeLists(other *SkipList, onlyA, onlyB func(e ListElement) bool, both func(a, b ListElement) []ListElement) []ListElement {
+
+	var elements []ListElement
+
+	a := t.startLevels[0]
+	var b *SkipListElement
+	if other != nil {
+		b = other.startLevels[0]
+	}
+	for a != nil || b != nil {
+		switch {
+		case b == nil || a != nil && b.key-a.key > t.eps:
+			if onlyA(a.value) {
+				elements = append(elements, a.value)
+			}
+			a = a.next[0]
+		case a == nil || a.key-b.key > t.eps:
+			if onlyB(b.value) {
+				elements = append(elements, b.value)
+			}
+			b = b.next[0]
+		default:
+			elements = append(elements, both(a.value, b.value)...)
+			a = a.next[0]
+			b = b.next[0]
+		}
+	}
+	return elements
+}
+
+// newFromMerged returns a new skiplist with the same settings as t, that contains the given sorted elements.
+func (t *SkipList) newFromMerged(elements []ListElement) *SkipList {
+	list := NewEps(t.eps)
+	list.copySettings(t)
+	if err := list.BulkLoad(elements); err != nil {
+		// mergeLists keeps the order of both skiplists. If other uses a larger eps, equal elements of other
+		// might still be out of order for t, so they are inserted one by one instead of being lost.
+		for _, e := range elements {
+			list.Insert(e)
+		}
+	}
+	return &list
+}
+
+// Union returns a new skiplist with all elements of both skiplists.
+// Elements that are equal in both lists are only contained once, the one from t is kept.
+// Elements that are equal within one list are all kept (multiset union), unless the duplicate policy
+// of t forbids duplicates. Neither skiplist is changed.
+// Union runs in O(n + m)
+func (t *SkipList) Union(other *SkipList) *SkipList {
+	if t == nil {
+		return nil
+	}
+	keep := func(e ListElement) bool { return true }
+	return t.newFromMerged(t.mergeLists(other, keep, keep, func(a, b ListElement) []ListElement {
+		return []ListElement{a}
+	}))
+}
+
+// Merge returns a new skiplist with all elements of both skiplists, including equal ones from both.
+// For equal elements, the ones from t come first. The duplicate policy of t decides, if equal elements
+// are kept, only the first is kept or the last one replaces all previous. Neither skiplist is changed.
+// Merge runs in O(n + m)
+func (t *SkipList) Merge(other *SkipList) *SkipList {
+	if t == nil {
+		return nil
+	}
+	keep := func(e ListElement) bool { return true }
+	return t.newFromMerged(t.mergeLists(other, keep, keep, func(a, b ListElement) []ListElement {
+		return []ListElement{a, b}
+	}))
+}
+
+// Intersection returns a new skiplist with the elements of t, that have an equal element in other.
+// Neither skiplist is changed.
+// Intersection runs in O(n + m)
+func (t *SkipList) Intersection(other *SkipList) *SkipList {
+	if t == nil {
+		return nil
+	}
+	drop := func(e ListElement) bool { return false }
+	return t.newFromMerged(t.mergeLists(other, drop, drop, func(a, b ListElement) []ListElement {
+		return []ListElement{a}
+	}))
+}
+
+// Difference returns a new skiplist with the elements of t, that have no equal element in other.
+// Neither skiplist is changed.
+// Difference runs in O(n + m)
+func (t *SkipList) Difference(other *SkipList) *SkipList {
+	if t == nil {
+		return nil
+	}
+	keep := func(e ListElement) bool { return true }
+	drop := func(e ListElement) bool { return false }
+	return t.newFromMerged(t.mergeLists(other, keep, drop, func(a, b ListElement) []ListElement {
+		return nil
+	}))
+}
+
+// MergeFrom moves all elements of other into t and leaves other empty (consuming merge).
+// The duplicate policy of t is applied to equal elements.
+// MergeFrom runs in O(n + m)
+func (t *SkipList) MergeFrom(other *SkipList) {
+	if t == nil || other == nil || t == other {
+		return
+	}
+	merged := t.Merge(other)
+
+	// Keep all settings of t, but take over the new nodes.
+	t.startLevels = merged.startLevels
+	t.endLevels = merged.endLevels
+	t.startWidth = merged.startWidth
+	t.maxLevel = merged.maxLevel
+	t.elementCount = merged.elementCount
+
+	other.clear()
+}
+
+// clear removes all elements from the skiplist in O(1). The nodes are left to the garbage collector.
+func (t *SkipList) clear() {
+	t.startLevels = [maxHeight]*SkipListElement{}
+	t.endLevels = [maxHeight]*SkipListElement{}
+	t.startWidth = [maxHeight]int{}
+	t.maxLevel = 0
+	t.elementCount = 0
+}
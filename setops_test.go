@@ -0,0 +1,128 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func newFromKeys(keys ...int) *SkipList {
+	list := New()
+	for _, k := range keys {
+		list.Insert(Element(k))
+	}
+	return &list
+}
+
+func keysOf(list *SkipList) []Element {
+	var keys []Element
+	for v := range list.All() {
+		keys = append(keys, v.(Element))
+	}
+	return keys
+}
+
+func TestSetOperations(t *testing.T) {
+	a := newFromKeys(1, 2, 2, 3, 5, 8, 8, 8)
+	b := newFromKeys(2, 3, 4, 8, 9)
+
+	tests := []struct {
+		result   *SkipList
+		expected []Element
+	}{
+		{a.Union(b), []Element{1, 2, 2, 3, 4, 5, 8, 8, 8, 9}},
+		{a.Merge(b), []Element{1, 2, 2, 2, 3, 3, 4, 5, 8, 8, 8, 8, 9}},
+		{a.Intersection(b), []Element{2, 3, 8}},
+		{a.Difference(b), []Element{1, 2, 5, 8, 8}},
+		{b.Difference(a), []Element{4, 9}},
+		{a.Union(nil), []Element{1, 2, 2, 3, 5, 8, 8, 8}},
+	}
+	for i, test := range tests {
+		checkStructure(t, test.result)
+		if keys := keysOf(test.result); !slices.Equal(keys, test.expected) {
+			t.Errorf("operation %v: got %v, expected %v", i, keys, test.expected)
+		}
+	}
+
+	// Neither list is changed.
+	if a.GetNodeCount() != 8 || b.GetNodeCount() != 5 {
+		t.Fail()
+	}
+
+	// With unique elements, the set operations are classic set algebra.
+	a.SetDuplicatePolicy(RejectDuplicates)
+	if keys := keysOf(a.Merge(b)); !slices.Equal(keys, []Element{1, 2, 3, 4, 5, 8, 9}) {
+		t.Fail()
+	}
+
+	// Keys are equal within the eps of the first list.
+	c := NewEps(0.1)
+	c.Insert(FloatElement(2.05))
+	c.Insert(FloatElement(4.5))
+	if d := c.Difference(b); d.GetNodeCount() != 1 || d.GetSmallestNode().GetValue().(FloatElement) != 4.5 {
+		t.Fail()
+	}
+}
+
+func TestMergeFrom(t *testing.T) {
+	a := newFromKeys(1, 3, 5)
+	b := newFromKeys(2, 3, 4)
+
+	a.MergeFrom(b)
+	checkStructure(t, a)
+	checkStructure(t, b)
+	if keys := keysOf(a); !slices.Equal(keys, []Element{1, 2, 3, 3, 4, 5}) {
+		t.Fail()
+	}
+	if !b.IsEmpty() || b.GetNodeCount() != 0 {
+		t.Fail()
+	}
+
+	// Both lists are still usable.
+	b.Insert(Element(7))
+	a.Insert(Element(0))
+	checkStructure(t, a)
+	checkStructure(t, b)
+}
+
+func TestSetOperationsNearEqual(t *testing.T) {
+	newFromFloats := func(keys ...float64) *SkipList {
+		list := New()
+		for _, k := range keys {
+			list.Insert(FloatElement(k))
+		}
+		return &list
+	}
+	a := newFromFloats(1.000008)
+	b := newFromFloats(1.0, 1.000001)
+
+	// Equal elements (eps) from both lists might be in any raw order.
+	union := a.Union(b)
+	checkStructure(t, union)
+	if union.GetNodeCount() != 2 {
+		t.Errorf("union has %v elements, expected 2", union.GetNodeCount())
+	}
+	merged := a.Merge(b)
+	checkStructure(t, merged)
+	if merged.GetNodeCount() != 3 || merged.GetSmallestNode().GetValue() != FloatElement(1.000008) {
+		t.Errorf("merge has %v elements, expected 3", merged.GetNodeCount())
+	}
+	if a.Intersection(b).GetNodeCount() != 1 || b.Difference(a).GetNodeCount() != 1 {
+		t.Fail()
+	}
+
+	a.MergeFrom(b)
+	checkStructure(t, a)
+	if a.GetNodeCount() != 3 || !b.IsEmpty() {
+		t.Fail()
+	}
+
+	// Elements, that are only equal for the larger eps of other, are not lost either.
+	wide := NewEps(0.1)
+	wide.Insert(FloatElement(1.05))
+	wide.Insert(FloatElement(1.0))
+	union = newFromFloats(2).Union(&wide)
+	checkStructure(t, union)
+	if union.GetNodeCount() != 3 || union.GetSmallestNode().GetValue() != FloatElement(1.0) {
+		t.Errorf("union has %v elements, expected 3", union.GetNodeCount())
+	}
+}
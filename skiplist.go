@@ -38,8 +38,35 @@ import (
 const (
 	// maxLevel denotes the maximum height of the skiplist. This height will keep the skiplist
 	// efficient for up to 34m entries. If there is a need for much more, please adjust this constant accordingly.
+	// A SkipList only uses it as its default and can be configured with SetMaxHeight up to maxHeight.
 	maxLevel = 25
-	eps      = 0.00001
+	// maxHeight is the upper limit for the height of a SkipList.
+	maxHeight = 64
+	eps       = 0.00001
+)
+
+// Common probabilities for a node to be promoted to the next level.
+const (
+	// ProbabilityHalf is the default. It gives the fastest searches.
+	ProbabilityHalf = 0.5
+	// ProbabilityQuarter needs less than half the links of ProbabilityHalf for slightly slower searches.
+	ProbabilityQuarter = 0.25
+	// ProbabilityInverseE is the theoretical optimum for the expected search cost.
+	ProbabilityInverseE = 1 / math.E
+)
+
+// DuplicatePolicy defines, how Insert handles an element that is equal (eps) to an element already in the skiplist.
+type DuplicatePolicy int
+
+const (
+	// AllowDuplicates inserts equal elements after all existing ones, so they keep their insertion order (FIFO).
+	// This is the default policy, the skiplist is a multiset.
+	AllowDuplicates DuplicatePolicy = iota
+	// RejectDuplicates does not insert equal elements. Insert returns ok == false instead.
+	RejectDuplicates
+	// ReplaceDuplicates replaces the value of the first equal element in place (Upsert).
+	// The skiplist can be used as a map.
+	ReplaceDuplicates
 )
 
 // ListElement is the interface to implement for elements that are inserted into the skiplist.
@@ -52,44 +79,125 @@ type ListElement interface {
 
 // SkipListElement represents one actual Node in the skiplist structure.
 // It saves the actual element, pointers to the next nodes and a pointer to one previous node.
+// Every link to a next node also saves its width, the number of nodes it skips, which allows positional access.
+// next and width only have level+1 entries, as most nodes only exist on the lowest levels.
 type SkipListElement struct {
-	next  [maxLevel]*SkipListElement
-	level int
 	key   float64
+	next  []*SkipListElement
+	width []int
+	level int
 	value ListElement
 	prev  *SkipListElement
 }
 
+// newNode returns a node with a tower for the given level.
+// The tower is allocated together with the node, so following a link stays a single memory access.
+// Towers are rounded up to the next power of two, which keeps the number of node types small.
+func newNode(level int, key float64, value ListElement) *SkipListElement {
+	var e *SkipListElement
+	switch {
+	case level < 1:
+		n := new(struct {
+			elem  SkipListElement
+			next  [1]*SkipListElement
+			width [1]int
+		})
+		e = &n.elem
+		e.next, e.width = n.next[:], n.width[:]
+	case level < 2:
+		n := new(struct {
+			elem  SkipListElement
+			next  [2]*SkipListElement
+			width [2]int
+		})
+		e = &n.elem
+		e.next, e.width = n.next[:], n.width[:]
+	case level < 4:
+		n := new(struct {
+			elem  SkipListElement
+			next  [4]*SkipListElement
+			width [4]int
+		})
+		e = &n.elem
+		e.next, e.width = n.next[:level+1], n.width[:level+1]
+	case level < 8:
+		n := new(struct {
+			elem  SkipListElement
+			next  [8]*SkipListElement
+			width [8]int
+		})
+		e = &n.elem
+		e.next, e.width = n.next[:level+1], n.width[:level+1]
+	case level < 16:
+		n := new(struct {
+			elem  SkipListElement
+			next  [16]*SkipListElement
+			width [16]int
+		})
+		e = &n.elem
+		e.next, e.width = n.next[:level+1], n.width[:level+1]
+	case level < 32:
+		n := new(struct {
+			elem  SkipListElement
+			next  [32]*SkipListElement
+			width [32]int
+		})
+		e = &n.elem
+		e.next, e.width = n.next[:level+1], n.width[:level+1]
+	default:
+		n := new(struct {
+			elem  SkipListElement
+			next  [maxHeight]*SkipListElement
+			width [maxHeight]int
+		})
+		e = &n.elem
+		e.next, e.width = n.next[:level+1], n.width[:level+1]
+	}
+	e.level = level
+	e.key = key
+	e.value = value
+	return e
+}
+
 // SkipList is the actual skiplist representation.
 // It saves all nodes accessible from the start and end and keeps track of element count, eps and levels.
+// Every skiplist owns its random source for the node levels, so lists never share or touch global random state.
+// A SkipList must not be copied after first use, as the copy would share all nodes. Use Clone instead.
+// go vet reports accidental copies.
 type SkipList struct {
-	startLevels  [maxLevel]*SkipListElement
-	endLevels    [maxLevel]*SkipListElement
-	maxNewLevel  int
-	maxLevel     int
-	elementCount int
-	eps          float64
+	noCopy          noCopy
+	startLevels     [maxHeight]*SkipListElement
+	endLevels       [maxHeight]*SkipListElement
+	startWidth      [maxHeight]int
+	maxNewLevel     int
+	maxLevel        int
+	elementCount    int
+	eps             float64
+	random          *rand.Rand
+	probability     float64
+	autoGrow        bool
+	growThreshold   int
+	duplicatePolicy DuplicatePolicy
+	codec           ValueCodec
+	jsonOptions     JSONOptions
 }
 
 // NewSeedEps returns a new empty, initialized Skiplist.
-// Given a seed, a deterministic height/list behaviour can be achieved.
+// Given a seed, a deterministic height/list behaviour can be achieved, independent of any other list.
 // Eps is used to compare keys given by the ExtractKey() function on equality.
 func NewSeedEps(seed int64, eps float64) SkipList {
 
-	// Initialize random number generator.
-	rand.Seed(seed)
-	//fmt.Printf("SkipList seed: %v\n", seed)
-
-	list := SkipList{
-		startLevels:  [maxLevel]*SkipListElement{},
-		endLevels:    [maxLevel]*SkipListElement{},
-		maxNewLevel:  maxLevel,
-		maxLevel:     0,
-		elementCount: 0,
-		eps:          eps,
+	return SkipList{
+		startLevels:   [maxHeight]*SkipListElement{},
+		endLevels:     [maxHeight]*SkipListElement{},
+		maxNewLevel:   maxLevel,
+		maxLevel:      0,
+		elementCount:  0,
+		eps:           eps,
+		random:        rand.New(rand.NewSource(seed)),
+		probability:   ProbabilityHalf,
+		growThreshold: capacity(maxLevel, ProbabilityHalf),
 	}
-
-	return list
 }
 
 // NewEps returns a new empty, initialized Skiplist.
@@ -109,24 +217,113 @@ func New() SkipList {
 	return NewSeedEps(time.Now().UTC().UnixNano(), eps)
 }
 
+// SetDuplicatePolicy changes, how Insert handles elements equal to an element already in the skiplist.
+// It does not change elements that are already inserted.
+func (t *SkipList) SetDuplicatePolicy(policy DuplicatePolicy) {
+	t.duplicatePolicy = policy
+}
+
+// GetDuplicatePolicy returns the current duplicate policy of the skiplist.
+func (t *SkipList) GetDuplicatePolicy() DuplicatePolicy {
+	return t.duplicatePolicy
+}
+
 // IsEmpty checks, if the skiplist is empty.
 func (t *SkipList) IsEmpty() bool {
 	return t.startLevels[0] == nil
 }
 
 func (t *SkipList) generateLevel(maxLevel int) int {
-	level := maxLevel - 1
-	// First we apply some mask which makes sure that we don't get a level
-	// above our desired level. Then we find the first set bit.
-	var x uint64 = rand.Uint64() & ((1 << uint(maxLevel-1)) - 1)
-	zeroes := bits.TrailingZeros64(x)
-	if zeroes <= maxLevel {
-		level = zeroes
+
+	switch t.probability {
+	case ProbabilityHalf:
+		level := maxLevel - 1
+		// First we apply some mask which makes sure that we don't get a level
+		// above our desired level. Then we find the first set bit.
+		var x uint64 = t.random.Uint64() & ((1 << uint(maxLevel-1)) - 1)
+		zeroes := bits.TrailingZeros64(x)
+		if zeroes < maxLevel {
+			level = zeroes
+		}
+		return level
+	case ProbabilityQuarter:
+		// Every level needs two more zero bits.
+		return min(bits.TrailingZeros64(t.random.Uint64())/2, maxLevel-1)
 	}
 
+	level := 0
+	for level < maxLevel-1 && t.random.Float64() < t.probability {
+		level++
+	}
 	return level
 }
 
+// capacity returns the number of elements, that a skiplist with the given height and probability
+// can hold efficiently.
+func capacity(height int, probability float64) int {
+	c := math.Pow(1/probability, float64(height))
+	if c >= math.MaxInt {
+		return math.MaxInt
+	}
+	return int(c)
+}
+
+// growHeight raises the maximum height by one, if auto-grow is enabled and the skiplist
+// outgrew its current height.
+func (t *SkipList) growHeight() {
+	if t.autoGrow && t.elementCount >= t.growThreshold && t.maxNewLevel < maxHeight {
+		t.SetMaxHeight(t.maxNewLevel + 1)
+	}
+}
+
+// SetMaxHeight sets the maximum height of new nodes. It is limited to [1, 64].
+// The default of 25 keeps the skiplist efficient for up to 34m entries with ProbabilityHalf.
+// Existing nodes keep their height.
+func (t *SkipList) SetMaxHeight(height int) {
+	t.maxNewLevel = min(max(height, 1), maxHeight)
+	t.growThreshold = capacity(t.maxNewLevel, t.probability)
+}
+
+// GetMaxHeight returns the maximum height of new nodes.
+func (t *SkipList) GetMaxHeight() int {
+	return t.maxNewLevel
+}
+
+// SetProbability sets the probability for a new node to be promoted to the next level.
+// A smaller probability saves memory, as the nodes have fewer links, but makes searches slower.
+// probability must be in (0, 1), otherwise ProbabilityHalf is used.
+func (t *SkipList) SetProbability(probability float64) {
+	if !(probability > 0 && probability < 1) {
+		probability = ProbabilityHalf
+	}
+	t.probability = probability
+	t.growThreshold = capacity(t.maxNewLevel, t.probability)
+}
+
+// GetProbability returns the probability for a new node to be promoted to the next level.
+func (t *SkipList) GetProbability() float64 {
+	return t.probability
+}
+
+// SetAutoGrow enables or disables raising the maximum height automatically.
+// With auto-grow, the maximum height is increased by one every time the number of elements exceeds,
+// what the current height can hold efficiently, up to a height of 64.
+// This allows starting with a small height (SetMaxHeight) for small skiplists without limiting large ones.
+func (t *SkipList) SetAutoGrow(autoGrow bool) {
+	t.autoGrow = autoGrow
+}
+
+// copySettings copies all settings, that do not depend on the elements, from other.
+func (t *SkipList) copySettings(other *SkipList) {
+	t.maxNewLevel = other.maxNewLevel
+	t.probability = other.probability
+	t.autoGrow = other.autoGrow
+	t.growThreshold = other.growThreshold
+	t.duplicatePolicy = other.duplicatePolicy
+	t.codec = other.codec
+	t.jsonOptions = other.jsonOptions
+}
+
 func (t *SkipList) findEntryIndex(key float64, level int) int {
 	// Find good entry point so we don't accidentally skip half the list.
 	for i := t.maxLevel; i >= 0; i-- {
@@ -222,200 +419,556 @@ func (t *SkipList) FindGreaterOrEqual(e ListElement) (elem *SkipListElement, ok
 	return
 }
 
-// Delete removes an element equal to e from the skiplist, if there is one.
-// If there are multiple entries with the same value, Delete will remove one of them
-// (Which one will change based on the actual skiplist layout)
-// Delete runs in approx. O(log(n))
-func (t *SkipList) Delete(e ListElement) {
+// nextNode returns the next node after e on the given level.
+// A nil e stands for the start of the skiplist.
+func (t *SkipList) nextNode(e *SkipListElement, level int) *SkipListElement {
+	if e == nil {
+		return t.startLevels[level]
+	}
+	return e.next[level]
+}
 
-	if t == nil || t.IsEmpty() || e == nil {
-		return
+// width returns a pointer to the width of the link after e on the given level.
+// The width is the number of nodes the link skips on level 0 (The distance of their indices).
+// If there is no next node, it is the distance to the (virtual) index after the last node.
+// A nil e stands for the start of the skiplist, which has the virtual index -1.
+func (t *SkipList) width(e *SkipListElement, level int) *int {
+	if e == nil {
+		return &t.startWidth[level]
 	}
+	return &e.width[level]
+}
 
-	key := e.ExtractKey()
+// findInsertPosition fills update with the last node on every level, whose key is smaller or equal to key
+// and ranks with their indices. A new node with this key is inserted after all equal nodes.
+func (t *SkipList) findInsertPosition(key float64, update *[maxHeight]*SkipListElement, ranks *[maxHeight]int) {
 
-	index := t.findEntryIndex(key, 0)
+	// Equal keys (eps) are inserted after all existing ones, even if their raw value is smaller.
+	// So all comparisons skip over equal nodes.
 
-	var currentNode *SkipListElement
-	nextNode := currentNode
+	// New first element. Nothing to do, as the start is referenced by nil.
+	if t.IsEmpty() || t.startLevels[0].key-key > t.eps {
+		for i := 0; i <= t.maxLevel; i++ {
+			ranks[i] = -1
+		}
+		return
+	}
 
-	for {
+	// New last element. Appending needs no search at all.
+	if t.endLevels[0].key-key <= t.eps {
+		for i := 0; i <= t.maxLevel; i++ {
+			update[i] = t.endLevels[i]
+			ranks[i] = -1
+			if update[i] != nil {
+				ranks[i] = t.elementCount - update[i].width[i]
+			}
+		}
+		return
+	}
 
-		if currentNode == nil {
-			nextNode = t.startLevels[index]
-		} else {
-			nextNode = currentNode.next[index]
+	var currentNode *SkipListElement
+	rank := -1
+	for index := t.maxLevel; index >= 0; index-- {
+		for {
+			nextNode := t.nextNode(currentNode, index)
+			if nextNode == nil || nextNode.key-key > t.eps {
+				break
+			}
+			// Go right
+			rank += *t.width(currentNode, index)
+			currentNode = nextNode
 		}
+		update[index] = currentNode
+		ranks[index] = rank
+	}
+}
 
-		// Found and remove!
-		if nextNode != nil && math.Abs(nextNode.key-key) <= t.eps {
+// findDeletePosition fills update with the last node on every level, whose key is smaller and not equal (eps) to key.
+// It returns the first node with a key equal to key, if there is one.
+func (t *SkipList) findDeletePosition(key float64, update *[maxHeight]*SkipListElement) *SkipListElement {
 
-			if currentNode != nil {
-				currentNode.next[index] = nextNode.next[index]
+	var currentNode *SkipListElement
+	for index := t.maxLevel; index >= 0; index-- {
+		for {
+			nextNode := t.nextNode(currentNode, index)
+			if nextNode == nil || key-nextNode.key <= t.eps {
+				break
 			}
+			// Go right
+			currentNode = nextNode
+		}
+		update[index] = currentNode
+	}
 
-			if index == 0 {
-				if nextNode.next[index] != nil {
-					nextNode.next[index].prev = currentNode
-				}
-				t.elementCount--
-			}
+	node := t.nextNode(currentNode, 0)
+	if node == nil || math.Abs(node.key-key) > t.eps {
+		return nil
+	}
+	return node
+}
 
-			// Link from start needs readjustments.
-			if t.startLevels[index] == nextNode {
-				t.startLevels[index] = nextNode.next[index]
-				// This was our currently highest node!
-				if t.startLevels[index] == nil {
-					t.maxLevel = index - 1
-				}
-			}
+// findLast returns the last node with a key smaller than key or, if orEqual is set, smaller or equal to key.
+// Keys are compared on equality with eps. nil is returned, if there is no such node.
+func (t *SkipList) findLast(key float64, orEqual bool) *SkipListElement {
 
-			// Link from end needs readjustments.
-			if nextNode.next[index] == nil {
-				t.endLevels[index] = currentNode
+	var currentNode *SkipListElement
+	for index := t.maxLevel; index >= 0; index-- {
+		for {
+			nextNode := t.nextNode(currentNode, index)
+			if nextNode == nil {
+				break
+			}
+			if orEqual && nextNode.key-key > t.eps || !orEqual && key-nextNode.key <= t.eps {
+				break
 			}
-			nextNode.next[index] = nil
+			// Go right
+			currentNode = nextNode
 		}
+	}
+	return currentNode
+}
+
+// findIndexPosition fills update with the last node on every level, whose index is smaller than index.
+// It returns the node at the given index.
+func (t *SkipList) findIndexPosition(index int, update *[maxHeight]*SkipListElement) *SkipListElement {
 
-		if nextNode != nil && nextNode.key < key {
+	var currentNode *SkipListElement
+	rank := -1
+	for level := t.maxLevel; level >= 0; level-- {
+		for {
+			nextNode := t.nextNode(currentNode, level)
+			w := *t.width(currentNode, level)
+			if nextNode == nil || rank+w >= index {
+				break
+			}
 			// Go right
+			rank += w
 			currentNode = nextNode
+		}
+		update[level] = currentNode
+	}
+	return t.nextNode(currentNode, 0)
+}
+
+// linkNode links elem after the nodes in update on all of its levels and corrects the widths of all links.
+// It returns the index of elem.
+func (t *SkipList) linkNode(elem *SkipListElement, update *[maxHeight]*SkipListElement, ranks *[maxHeight]int) int {
+
+	index := ranks[0] + 1
+
+	for i := 0; i <= elem.level; i++ {
+		// An empty level spans the whole list.
+		if t.startLevels[i] == nil {
+			t.startWidth[i] = t.elementCount + 1
+		}
+
+		w := t.width(update[i], i)
+		elem.next[i] = t.nextNode(update[i], i)
+		elem.width[i] = *w - (index - ranks[i]) + 1
+		*w = index - ranks[i]
+
+		if update[i] == nil {
+			t.startLevels[i] = elem
 		} else {
-			// Go down
-			index--
-			if index < 0 {
-				break
-			}
+			update[i].next[i] = elem
 		}
+		// Link from end needs readjustments.
+		if elem.next[i] == nil {
+			t.endLevels[i] = elem
+		}
+	}
+
+	// All links above elem now skip one more node.
+	for i := elem.level + 1; i <= t.maxLevel; i++ {
+		*t.width(update[i], i) += 1
+	}
+
+	elem.prev = update[0]
+	if elem.next[0] != nil {
+		elem.next[0].prev = elem
 	}
 
+	t.elementCount++
+	return index
 }
 
-// Insert inserts the given ListElement into the skiplist.
-// Insert runs in approx. O(log(n))
-func (t *SkipList) Insert(e ListElement) {
+// unlinkNode removes node from all levels, given the nodes before it in update, and corrects the widths of all links.
+func (t *SkipList) unlinkNode(node *SkipListElement, update *[maxHeight]*SkipListElement) {
+
+	if node.next[0] != nil {
+		node.next[0].prev = node.prev
+	}
+	node.prev = nil
+
+	for i := 0; i <= t.maxLevel; i++ {
+		w := t.width(update[i], i)
+
+		if i > node.level {
+			// All links above node now skip one node less.
+			*w -= 1
+			continue
+		}
+
+		*w += node.width[i] - 1
+
+		if update[i] == nil {
+			t.startLevels[i] = node.next[i]
+		} else {
+			update[i].next[i] = node.next[i]
+		}
+		// Link from end needs readjustments.
+		if node.next[i] == nil {
+			t.endLevels[i] = update[i]
+		}
+		node.next[i] = nil
+		node.width[i] = 0
+	}
+
+	// The highest levels might be empty now.
+	for t.maxLevel >= 0 && t.startLevels[t.maxLevel] == nil {
+		t.maxLevel--
+	}
+
+	t.elementCount--
+}
+
+// FindLessOrEqual finds the last element, that is less or equal to the given ListElement e.
+// The comparison is done on the keys (So on ExtractKey()).
+// FindLessOrEqual runs in approx. O(log(n))
+func (t *SkipList) FindLessOrEqual(e ListElement) (elem *SkipListElement, ok bool) {
 
 	if t == nil || e == nil {
 		return
 	}
 
-	level := t.generateLevel(t.maxNewLevel)
+	elem = t.findLast(e.ExtractKey(), true)
+	return elem, elem != nil
+}
 
-	// Only grow the height of the skiplist by one at a time!
-	if level > t.maxLevel {
-		level = t.maxLevel + 1
-		t.maxLevel = level
+// FindLess finds the last element, that is less than (and not equal to) the given ListElement e.
+// The comparison is done on the keys (So on ExtractKey()).
+// FindLess runs in approx. O(log(n))
+func (t *SkipList) FindLess(e ListElement) (elem *SkipListElement, ok bool) {
+
+	if t == nil || e == nil {
+		return
 	}
 
-	elem := &SkipListElement{
-		next:  [maxLevel]*SkipListElement{},
-		level: level,
-		key:   e.ExtractKey(),
-		value: e,
+	elem = t.findLast(e.ExtractKey(), false)
+	return elem, elem != nil
+}
+
+// FindGreater finds the first element, that is greater than (and not equal to) the given ListElement e.
+// The comparison is done on the keys (So on ExtractKey()).
+// FindGreater runs in approx. O(log(n))
+func (t *SkipList) FindGreater(e ListElement) (elem *SkipListElement, ok bool) {
+
+	if t == nil || e == nil {
+		return
 	}
 
-	t.elementCount++
+	elem = t.nextNode(t.findLast(e.ExtractKey(), true), 0)
+	return elem, elem != nil
+}
+
+// FindNearest finds the element with the key closest to the key of the given ListElement e.
+// If both neighbours are equally close, the smaller one is returned.
+// FindNearest runs in approx. O(log(n))
+func (t *SkipList) FindNearest(e ListElement) (elem *SkipListElement, ok bool) {
 
-	newFirst := true
-	newLast := true
-	if !t.IsEmpty() {
-		newFirst = elem.key < t.startLevels[0].key
-		newLast = elem.key > t.endLevels[0].key
+	if t == nil || e == nil || t.IsEmpty() {
+		return
 	}
 
-	normallyInserted := false
-	if !newFirst && !newLast {
+	key := e.ExtractKey()
+	elem = t.findLast(key, true)
+	next := t.nextNode(elem, 0)
 
-		normallyInserted = true
+	if elem == nil || next != nil && next.key-key < key-elem.key {
+		elem = next
+	}
+	return elem, true
+}
 
-		index := t.findEntryIndex(elem.key, level)
+// Delete removes an element equal to e from the skiplist, if there is one.
+// If there are multiple entries with the same value, Delete will remove the one that was inserted first.
+// Delete runs in approx. O(log(n))
+func (t *SkipList) Delete(e ListElement) {
 
-		var currentNode *SkipListElement
-		nextNode := t.startLevels[index]
+	if t == nil || t.IsEmpty() || e == nil {
+		return
+	}
 
-		for {
+	var update [maxHeight]*SkipListElement
+	if node := t.findDeletePosition(e.ExtractKey(), &update); node != nil {
+		t.unlinkNode(node, &update)
+	}
+}
 
-			if currentNode == nil {
-				nextNode = t.startLevels[index]
-			} else {
-				nextNode = currentNode.next[index]
-			}
+// findNodePosition fills update with the last node before node on every level and returns the index of node.
+// ok is false, if node is not part of the skiplist.
+func (t *SkipList) findNodePosition(node *SkipListElement, update *[maxHeight]*SkipListElement) (index int, ok bool) {
 
-			// Connect node to next
-			if index <= level && (nextNode == nil || nextNode.key > elem.key) {
-				elem.next[index] = nextNode
-				if currentNode != nil {
-					currentNode.next[index] = elem
-				}
-				if index == 0 {
-					elem.prev = currentNode
-					if nextNode != nil {
-						nextNode.prev = elem
-					}
-				}
-			}
+	// Following the highest link of every node to the end sums up the distance of node to the end.
+	distance := 0
+	for currentNode := node; currentNode != nil; currentNode = currentNode.next[currentNode.level] {
+		distance += currentNode.width[currentNode.level]
+	}
 
-			if nextNode != nil && nextNode.key <= elem.key {
-				// Go right
-				currentNode = nextNode
-			} else {
-				// Go down
-				index--
-				if index < 0 {
-					break
-				}
-			}
+	index = t.elementCount - distance
+	if distance == 0 || index < 0 {
+		return -1, false
+	}
+	return index, t.findIndexPosition(index, update) == node
+}
+
+// DeleteNode removes exactly the given node from the skiplist, even if there are other equal elements.
+// ok is false, if node is not part of the skiplist (anymore).
+// DeleteNode runs in approx. O(log(n))
+func (t *SkipList) DeleteNode(node *SkipListElement) (ok bool) {
+
+	if t == nil || t.IsEmpty() || node == nil {
+		return
+	}
+
+	var update [maxHeight]*SkipListElement
+	if _, ok = t.findNodePosition(node, &update); ok {
+		t.unlinkNode(node, &update)
+	}
+	return
+}
+
+// DeleteValue removes the first element equal to e, for which equal(element, e) returns true.
+// This allows removing a specific value among elements with equal keys.
+// ok is false, if there is no such element.
+// DeleteValue runs in approx. O(log(n) + k) for k equal elements.
+func (t *SkipList) DeleteValue(e ListElement, equal func(a, b ListElement) bool) (ok bool) {
+
+	if t == nil || t.IsEmpty() || e == nil {
+		return
+	}
+
+	key := e.ExtractKey()
+
+	var update [maxHeight]*SkipListElement
+	for node := t.findDeletePosition(key, &update); node != nil && math.Abs(node.key-key) <= t.eps; node = node.next[0] {
+		if equal(node.value, e) {
+			t.unlinkNode(node, &update)
+			return true
+		}
+		// node is now the last node before the next candidate on all of its levels.
+		for i := 0; i <= node.level; i++ {
+			update[i] = node
 		}
 	}
+	return false
+}
 
-	// Where we have a left-most position that needs to be referenced!
-	for i := level; i >= 0; i-- {
+// FindAll returns all elements equal to e in the order they were inserted.
+// FindAll runs in approx. O(log(n) + k) for k equal elements.
+func (t *SkipList) FindAll(e ListElement) (elems []*SkipListElement) {
 
-		didSomething := false
+	if t == nil || t.IsEmpty() || e == nil {
+		return
+	}
 
-		if newFirst || normallyInserted {
+	key := e.ExtractKey()
+	node, _ := t.findFirstEqual(key)
+	for ; node != nil && math.Abs(node.key-key) <= t.eps; node = node.next[0] {
+		elems = append(elems, node)
+	}
+	return
+}
 
-			if t.startLevels[i] == nil || t.startLevels[i].key > elem.key {
-				if i == 0 && t.startLevels[i] != nil {
-					t.startLevels[i].prev = elem
-				}
-				elem.next[i] = t.startLevels[i]
-				t.startLevels[i] = elem
-			}
+// CountKey returns the number of elements equal to e.
+// CountKey runs in approx. O(log(n) + k) for k equal elements.
+func (t *SkipList) CountKey(e ListElement) (count int) {
 
-			// link the endLevels to this element!
-			if elem.next[i] == nil {
-				t.endLevels[i] = elem
-			}
+	if t == nil || t.IsEmpty() || e == nil {
+		return
+	}
+
+	key := e.ExtractKey()
+	node, _ := t.findFirstEqual(key)
+	for ; node != nil && math.Abs(node.key-key) <= t.eps; node = node.next[0] {
+		count++
+	}
+	return
+}
 
-			didSomething = true
+// DeleteAll removes all elements equal to e from the skiplist.
+// count is the number of removed elements.
+// DeleteAll runs in approx. O(log(n) + k) for k equal elements.
+func (t *SkipList) DeleteAll(e ListElement) (count int) {
+
+	if t == nil || t.IsEmpty() || e == nil {
+		return
+	}
+
+	key := e.ExtractKey()
+
+	var update [maxHeight]*SkipListElement
+	first := t.findDeletePosition(key, &update)
+	if first == nil {
+		return
+	}
+
+	// All equal elements follow each other, so count them first.
+	last := first
+	count = 1
+	for last.next[0] != nil && math.Abs(last.next[0].key-key) <= t.eps {
+		last = last.next[0]
+		count++
+	}
+
+	if last.next[0] != nil {
+		last.next[0].prev = first.prev
+	}
+	for node := first; node != last; node = node.next[0] {
+		node.prev = nil
+	}
+	last.prev = nil
+
+	// Unlink the whole block of equal elements on every level at once.
+	for i := 0; i <= t.maxLevel; i++ {
+		w := t.width(update[i], i)
+		nextNode := t.nextNode(update[i], i)
+		for nextNode != nil && math.Abs(nextNode.key-key) <= t.eps {
+			*w += nextNode.width[i]
+			removed := nextNode
+			nextNode = nextNode.next[i]
+			removed.next[i] = nil
+			removed.width[i] = 0
 		}
+		*w -= count
 
-		if newLast {
-			// Places the element after the very last element on this level!
-			// This is very important, so we are not linking the very first element (newFirst AND newLast) to itself!
-			if !newFirst {
-				if t.endLevels[i] != nil {
-					t.endLevels[i].next[i] = elem
-				}
-				if i == 0 {
-					elem.prev = t.endLevels[i]
-				}
-				t.endLevels[i] = elem
-			}
+		if update[i] == nil {
+			t.startLevels[i] = nextNode
+		} else {
+			update[i].next[i] = nextNode
+		}
+		// Link from end needs readjustments.
+		if nextNode == nil {
+			t.endLevels[i] = update[i]
+		}
+	}
 
-			// Link the startLevels to this element!
-			if t.startLevels[i] == nil || t.startLevels[i].key > elem.key {
-				t.startLevels[i] = elem
-			}
+	// The highest levels might be empty now.
+	for t.maxLevel >= 0 && t.startLevels[t.maxLevel] == nil {
+		t.maxLevel--
+	}
+
+	t.elementCount -= count
+	return
+}
+
+// Insert inserts the given ListElement into the skiplist.
+// Equal elements are handled according to the duplicate policy of the skiplist. By default, they are
+// inserted after all existing ones, so equal elements keep their insertion order.
+// index is the position of the new (or the already existing equal) element in the skiplist
+// (Starting with 0 for the smallest element). ok is false, if e was rejected as a duplicate.
+// Insert runs in approx. O(log(n))
+func (t *SkipList) Insert(e ListElement) (index int, ok bool) {
 
-			didSomething = true
+	if t == nil || e == nil {
+		return -1, false
+	}
+
+	key := e.ExtractKey()
+
+	if t.duplicatePolicy != AllowDuplicates {
+		if node, index := t.findFirstEqual(key); node != nil {
+			if t.duplicatePolicy == RejectDuplicates {
+				return index, false
+			}
+			node.value = e
+			return index, true
 		}
+	}
 
-		if !didSomething {
-			break
+	t.growHeight()
+	level := t.generateLevel(t.maxNewLevel)
+
+	// Only grow the height of the skiplist by one at a time!
+	if level > t.maxLevel {
+		level = t.maxLevel + 1
+		t.maxLevel = level
+	}
+
+	elem := newNode(level, key, e)
+
+	var update [maxHeight]*SkipListElement
+	var ranks [maxHeight]int
+	t.findInsertPosition(elem.key, &update, &ranks)
+
+	return t.linkNode(elem, &update, &ranks), true
+}
+
+// findFirstEqual returns the first node with a key equal (eps) to key and its index.
+// node is nil, if there is no such node.
+func (t *SkipList) findFirstEqual(key float64) (node *SkipListElement, index int) {
+
+	var currentNode *SkipListElement
+	rank := -1
+	for level := t.maxLevel; level >= 0; level-- {
+		for {
+			nextNode := t.nextNode(currentNode, level)
+			if nextNode == nil || key-nextNode.key <= t.eps {
+				break
+			}
+			// Go right
+			rank += *t.width(currentNode, level)
+			currentNode = nextNode
 		}
 	}
+
+	node = t.nextNode(currentNode, 0)
+	if node == nil || math.Abs(node.key-key) > t.eps {
+		return nil, -1
+	}
+	return node, rank + 1
+}
+
+// Rank returns the index of the first element equal to e (Starting with 0 for the smallest element).
+// ok is false, if there is no such element.
+// Rank runs in approx. O(log(n))
+func (t *SkipList) Rank(e ListElement) (index int, ok bool) {
+
+	if t == nil || t.IsEmpty() || e == nil {
+		return -1, false
+	}
+
+	node, index := t.findFirstEqual(e.ExtractKey())
+	return index, node != nil
+}
+
+// GetByIndex returns the node at the given index (Starting with 0 for the smallest element).
+// elem can be used, if ok is true.
+// GetByIndex runs in approx. O(log(n))
+func (t *SkipList) GetByIndex(index int) (elem *SkipListElement, ok bool) {
+
+	if t == nil || index < 0 || index >= t.elementCount {
+		return
+	}
+
+	var update [maxHeight]*SkipListElement
+	elem = t.findIndexPosition(index, &update)
+	return elem, elem != nil
+}
+
+// DeleteAt removes the element at the given index (Starting with 0 for the smallest element).
+// elem is the removed node and can be used, if ok is true.
+// DeleteAt runs in approx. O(log(n))
+func (t *SkipList) DeleteAt(index int) (elem *SkipListElement, ok bool) {
+
+	if t == nil || index < 0 || index >= t.elementCount {
+		return
+	}
+
+	var update [maxHeight]*SkipListElement
+	elem = t.findIndexPosition(index, &update)
+	t.unlinkNode(elem, &update)
+	return elem, true
 }
 
 // GetValue extracts the ListElement value from a skiplist node.
@@ -473,6 +1026,55 @@ func (t *SkipList) ChangeValue(e *SkipListElement, newValue ListElement) (ok boo
 	return
 }
 
+// UpdateKey changes the value of a node in the skiplist, even if the key from ExtractKey() changes.
+// The node is moved to its new position and keeps its tower, so e stays a valid handle to the element.
+// If the order to its neighbours does not change, the node is updated in place.
+// index is the new position of the node. ok is false, if e is not part of the skiplist or, if the skiplist
+// does not allow duplicates and there already is another element equal to newValue.
+// UpdateKey runs in approx. O(log(n))
+func (t *SkipList) UpdateKey(e *SkipListElement, newValue ListElement) (index int, ok bool) {
+
+	if t == nil || t.IsEmpty() || e == nil || newValue == nil {
+		return -1, false
+	}
+
+	var update [maxHeight]*SkipListElement
+	if index, ok = t.findNodePosition(e, &update); !ok {
+		return -1, false
+	}
+
+	newKey := newValue.ExtractKey()
+
+	if t.duplicatePolicy != AllowDuplicates {
+		if other, _ := t.findFirstEqual(newKey); other != nil && other != e {
+			return index, false
+		}
+	}
+
+	// Nothing changes for the neighbours, so there is no need to move the node.
+	if (e.prev == nil || e.prev.key <= newKey) && (e.next[0] == nil || newKey < e.next[0].key) {
+		e.key = newKey
+		e.value = newValue
+		return index, true
+	}
+
+	t.unlinkNode(e, &update)
+
+	e.key = newKey
+	e.value = newValue
+
+	// The skiplist might have shrunk below the tower of the node.
+	if e.level > t.maxLevel {
+		t.maxLevel = e.level
+	}
+
+	var ranks [maxHeight]int
+	update = [maxHeight]*SkipListElement{}
+	t.findInsertPosition(e.key, &update, &ranks)
+
+	return t.linkNode(e, &update, &ranks), true
+}
+
 // String returns a string format of the skiplist. Useful to get a graphical overview and/or debugging.
 func (t *SkipList) String() string {
 	s := ""
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 	//"github.com/pkg/profile"
@@ -364,3 +365,681 @@ func TestInfiniteLoop(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestSeedIsolation(t *testing.T) {
+	list1 := NewSeed(1531889620180049576)
+	for i := 0; i < 100; i++ {
+		list1.Insert(Element(i))
+	}
+
+	// Interleaving the inserts of differently seeded lists must not change the layout.
+	list2 := NewSeed(1531889620180049576)
+	list3 := NewSeed(42)
+	list4 := NewSeed(1531889620180049576)
+	for i := 0; i < 100; i++ {
+		list2.Insert(Element(i))
+		list3.Insert(Element(i))
+		list4.Insert(Element(i))
+		rand.Uint64()
+	}
+
+	if list1.String() != list2.String() || list1.String() != list4.String() {
+		t.Fail()
+	}
+}
+
+// checkStructure verifies all links, widths and counters of the skiplist.
+func checkStructure(t *testing.T, list *SkipList) {
+	t.Helper()
+
+	ranks := map[*SkipListElement]int{}
+	var prev *SkipListElement
+	for node := list.startLevels[0]; node != nil; node = node.next[0] {
+		if node.prev != prev || (prev != nil && prev.key-node.key > list.eps) {
+			t.Fatalf("wrong order or prev pointer at index %v", len(ranks))
+		}
+		ranks[node] = len(ranks)
+		prev = node
+	}
+	if len(ranks) != list.elementCount || list.endLevels[0] != prev {
+		t.Fatalf("wrong element count %v, expected %v", list.elementCount, len(ranks))
+	}
+
+	for i := 0; i < maxHeight; i++ {
+		if i > list.maxLevel {
+			if list.startLevels[i] != nil {
+				t.Fatalf("level %v is above maxLevel %v", i, list.maxLevel)
+			}
+			continue
+		}
+		if list.startLevels[i] == nil {
+			// The widths of empty levels are not used.
+			if list.elementCount > 0 {
+				t.Fatalf("level %v below maxLevel %v is empty", i, list.maxLevel)
+			}
+			continue
+		}
+		var node *SkipListElement
+		rank := -1
+		for {
+			next := list.nextNode(node, i)
+			expected := list.elementCount - rank
+			if next != nil {
+				expected = ranks[next] - rank
+			}
+			if *list.width(node, i) != expected {
+				t.Fatalf("wrong width %v on level %v at index %v, expected %v", *list.width(node, i), i, rank, expected)
+			}
+			if next == nil {
+				break
+			}
+			node = next
+			rank = ranks[node]
+		}
+		if list.endLevels[i] != node {
+			t.Fatalf("wrong end on level %v", i)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	list := New()
+
+	if _, ok := list.GetByIndex(0); ok {
+		t.Fail()
+	}
+	if _, ok := list.DeleteAt(0); ok {
+		t.Fail()
+	}
+	if _, ok := list.Rank(Element(0)); ok {
+		t.Fail()
+	}
+
+	n := 10000
+	for _, e := range rand.Perm(n) {
+		index, _ := list.Insert(Element(e * 2))
+		if v, ok := list.GetByIndex(index); !ok || v.GetValue().(Element) != Element(e*2) {
+			t.Fail()
+		}
+	}
+	checkStructure(t, &list)
+
+	for i := 0; i < n; i++ {
+		if v, ok := list.GetByIndex(i); !ok || v.GetValue().(Element) != Element(i*2) {
+			t.Fail()
+		}
+		if index, ok := list.Rank(Element(i * 2)); !ok || index != i {
+			t.Fail()
+		}
+		if _, ok := list.Rank(Element(i*2 + 1)); ok {
+			t.Fail()
+		}
+	}
+	if _, ok := list.GetByIndex(n); ok {
+		t.Fail()
+	}
+
+	// Delete every second element by index.
+	for i := 0; i < n/2; i++ {
+		if v, ok := list.DeleteAt(i); !ok || v.GetValue().(Element) != Element(i*4) {
+			t.Fail()
+		}
+	}
+	checkStructure(t, &list)
+
+	for i := 0; i < n/2; i++ {
+		if index, ok := list.Rank(Element(i*4 + 2)); !ok || index != i {
+			t.Fail()
+		}
+	}
+
+	for list.GetNodeCount() > 0 {
+		list.DeleteAt(rand.Intn(list.GetNodeCount()))
+	}
+	checkStructure(t, &list)
+	if !list.IsEmpty() {
+		t.Fail()
+	}
+}
+
+func TestIndexRandomOperations(t *testing.T) {
+	list := New()
+
+	for i := 0; i < 20000; i++ {
+		switch rand.Intn(4) {
+		case 0, 1:
+			list.Insert(Element(rand.Intn(1000)))
+		case 2:
+			list.Delete(Element(rand.Intn(1000)))
+		case 3:
+			if list.GetNodeCount() > 0 {
+				list.DeleteAt(rand.Intn(list.GetNodeCount()))
+			}
+		}
+		if i%1000 == 0 {
+			checkStructure(t, &list)
+		}
+	}
+	checkStructure(t, &list)
+}
+
+func TestNeighbours(t *testing.T) {
+	list := NewEps(0.001)
+
+	var listPointer *SkipList
+	if _, ok := listPointer.FindLessOrEqual(Element(0)); ok {
+		t.Fail()
+	}
+	if _, ok := list.FindNearest(Element(0)); ok {
+		t.Fail()
+	}
+
+	for i := 0; i < 100; i += 10 {
+		list.Insert(Element(i))
+	}
+
+	key := func(e *SkipListElement, ok bool) float64 {
+		if !ok {
+			return -1
+		}
+		return e.key
+	}
+
+	tests := []struct {
+		e                                   ListElement
+		lessOrEqual, less, greater, nearest float64
+	}{
+		{Element(50), 50, 40, 60, 50},
+		{FloatElement(50.0001), 50, 40, 60, 50},
+		{FloatElement(49.9999), 50, 40, 60, 50},
+		{FloatElement(52), 50, 50, 60, 50},
+		{FloatElement(57), 50, 50, 60, 60},
+		{FloatElement(55), 50, 50, 60, 50},
+		{Element(-5), -1, -1, 0, 0},
+		{Element(0), 0, -1, 10, 0},
+		{Element(90), 90, 80, -1, 90},
+		{Element(1000), 90, 90, -1, 90},
+	}
+
+	for _, test := range tests {
+		if key(list.FindLessOrEqual(test.e)) != test.lessOrEqual {
+			t.Errorf("FindLessOrEqual(%v) = %v", test.e, key(list.FindLessOrEqual(test.e)))
+		}
+		if key(list.FindLess(test.e)) != test.less {
+			t.Errorf("FindLess(%v) = %v", test.e, key(list.FindLess(test.e)))
+		}
+		if key(list.FindGreater(test.e)) != test.greater {
+			t.Errorf("FindGreater(%v) = %v", test.e, key(list.FindGreater(test.e)))
+		}
+		if key(list.FindNearest(test.e)) != test.nearest {
+			t.Errorf("FindNearest(%v) = %v", test.e, key(list.FindNearest(test.e)))
+		}
+	}
+}
+
+func TestDuplicateOrderEps(t *testing.T) {
+	values := func(list *SkipList) []FloatElement {
+		var v []FloatElement
+		for e := range list.All() {
+			v = append(v, e.(FloatElement))
+		}
+		return v
+	}
+
+	// Keys that are equal within eps keep their insertion order, even if their raw value is smaller.
+	list := New()
+	list.Insert(FloatElement(1.000001))
+	list.Insert(FloatElement(1.0))
+	list.Insert(FloatElement(0.999999))
+	checkStructure(t, &list)
+	if !slices.Equal(values(&list), []FloatElement{1.000001, 1.0, 0.999999}) {
+		t.Errorf("wrong order %v", values(&list))
+	}
+
+	list = New()
+	list.Insert(Element(0))
+	list.Insert(FloatElement(1.000001))
+	list.Insert(Element(5))
+	list.Insert(FloatElement(1.0))
+	checkStructure(t, &list)
+	if all := list.FindAll(Element(1)); len(all) != 2 || all[0].GetValue() != FloatElement(1.000001) {
+		t.Fail()
+	}
+	list.Delete(FloatElement(1.0))
+	if e, ok := list.Find(Element(1)); !ok || e.GetValue() != FloatElement(1.0) {
+		t.Fail()
+	}
+
+	// BulkLoad and Join accept equal keys in any raw order.
+	list = New()
+	if list.BulkLoad([]ListElement{FloatElement(1.000001), FloatElement(1.0), FloatElement(2)}) != nil {
+		t.Fail()
+	}
+	if list.BulkLoad([]ListElement{FloatElement(1.5)}) != ErrNotSorted {
+		t.Fail()
+	}
+	other := New()
+	other.Insert(FloatElement(1.999999))
+	if list.Join(&other) != nil || list.GetNodeCount() != 4 {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+}
+
+func TestDuplicatePolicy(t *testing.T) {
+	list := New()
+
+	if list.GetDuplicatePolicy() != AllowDuplicates {
+		t.Fail()
+	}
+
+	// Multiset, equal elements keep their insertion order.
+	for i := 0; i < 100; i++ {
+		if _, ok := list.Insert(ComplexElement{i % 10, fmt.Sprint(i)}); !ok {
+			t.Fail()
+		}
+	}
+	checkStructure(t, &list)
+	if list.GetNodeCount() != 100 {
+		t.Fail()
+	}
+	index := 0
+	for v := range list.All() {
+		if v.(ComplexElement).E != index/10 || v.(ComplexElement).S != fmt.Sprint(index%10*10+index/10) {
+			t.Fail()
+		}
+		index++
+	}
+	// Delete removes the first inserted one.
+	list.Delete(ComplexElement{5, ""})
+	if v, _ := list.GetByIndex(50); v.GetValue().(ComplexElement).S != "15" {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+
+	list = New()
+	list.SetDuplicatePolicy(RejectDuplicates)
+	for i := 0; i < 100; i++ {
+		index, ok := list.Insert(ComplexElement{i % 10, fmt.Sprint(i)})
+		if ok != (i < 10) || index != i%10 {
+			t.Fail()
+		}
+	}
+	if list.GetNodeCount() != 10 {
+		t.Fail()
+	}
+	if v, _ := list.Find(ComplexElement{3, ""}); v.GetValue().(ComplexElement).S != "3" {
+		t.Fail()
+	}
+
+	list = NewEps(0.01)
+	list.SetDuplicatePolicy(ReplaceDuplicates)
+	for i := 0; i < 100; i++ {
+		index, ok := list.Insert(ComplexElement{i % 10, fmt.Sprint(i)})
+		if !ok || index != i%10 {
+			t.Fail()
+		}
+	}
+	if _, ok := list.Insert(FloatElement(3.001)); !ok {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+	if list.GetNodeCount() != 10 {
+		t.Fail()
+	}
+	if v, _ := list.Find(ComplexElement{4, ""}); v.GetValue().(ComplexElement).S != "94" {
+		t.Fail()
+	}
+	if v, _ := list.Find(ComplexElement{3, ""}); v.GetValue().(FloatElement) != 3.001 {
+		t.Fail()
+	}
+}
+
+func TestFindAllDeleteAll(t *testing.T) {
+	list := NewEps(0.01)
+
+	if list.FindAll(Element(0)) != nil || list.CountKey(Element(0)) != 0 || list.DeleteAll(Element(0)) != 0 {
+		t.Fail()
+	}
+
+	for _, e := range rand.Perm(1000) {
+		list.Insert(ComplexElement{e % 50, fmt.Sprint(e)})
+	}
+	list.Insert(FloatElement(7.001))
+
+	elems := list.FindAll(ComplexElement{7, ""})
+	if len(elems) != 21 || list.CountKey(Element(7)) != 21 {
+		t.Fail()
+	}
+	// Insertion order among equal keys.
+	for i := 0; i < 20; i++ {
+		if elems[i] != elems[i+1].prev {
+			t.Fail()
+		}
+	}
+	if _, ok := elems[20].GetValue().(FloatElement); !ok {
+		t.Fail()
+	}
+	if len(list.FindAll(Element(50))) != 0 || list.CountKey(FloatElement(6.5)) != 0 {
+		t.Fail()
+	}
+
+	if list.DeleteAll(Element(7)) != 21 || list.CountKey(Element(7)) != 0 {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+	if list.GetNodeCount() != 980 {
+		t.Fail()
+	}
+
+	// Delete at both ends and everything else.
+	if list.DeleteAll(Element(0)) != 20 || list.DeleteAll(Element(49)) != 20 {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+	for i := 0; i < 50; i++ {
+		list.DeleteAll(Element(i))
+		checkStructure(t, &list)
+	}
+	if !list.IsEmpty() {
+		t.Fail()
+	}
+}
+
+func TestDeleteNode(t *testing.T) {
+	list := New()
+	other := New()
+
+	if list.DeleteNode(nil) {
+		t.Fail()
+	}
+
+	for i := 0; i < 1000; i++ {
+		list.Insert(ComplexElement{i % 10, fmt.Sprint(i)})
+		other.Insert(ComplexElement{i % 10, fmt.Sprint(i)})
+	}
+
+	// Delete a node in the middle of equal elements.
+	elems := list.FindAll(Element(5))
+	if !list.DeleteNode(elems[50]) {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+	for _, e := range list.FindAll(Element(5)) {
+		if e == elems[50] {
+			t.Fail()
+		}
+	}
+	// Not part of the list anymore.
+	if list.DeleteNode(elems[50]) {
+		t.Fail()
+	}
+	// Nodes of other lists are not deleted.
+	if list.DeleteNode(other.GetLargestNode()) || list.DeleteNode(other.GetSmallestNode()) {
+		t.Fail()
+	}
+	if list.GetNodeCount() != 999 {
+		t.Fail()
+	}
+
+	for list.GetNodeCount() > 0 {
+		index := rand.Intn(list.GetNodeCount())
+		node, _ := list.GetByIndex(index)
+		if !list.DeleteNode(node) {
+			t.Fail()
+		}
+	}
+	checkStructure(t, &list)
+}
+
+func TestDeleteValue(t *testing.T) {
+	list := New()
+
+	for i := 0; i < 100; i++ {
+		list.Insert(ComplexElement{i % 10, fmt.Sprint(i)})
+	}
+
+	sameString := func(a, b ListElement) bool {
+		return a.(ComplexElement).S == b.(ComplexElement).S
+	}
+
+	if !list.DeleteValue(ComplexElement{3, "53"}, sameString) {
+		t.Fail()
+	}
+	if list.DeleteValue(ComplexElement{3, "53"}, sameString) || list.DeleteValue(ComplexElement{4, "53"}, sameString) {
+		t.Fail()
+	}
+	checkStructure(t, &list)
+	for _, e := range list.FindAll(Element(3)) {
+		if e.GetValue().(ComplexElement).S == "53" {
+			t.Fail()
+		}
+	}
+	if list.CountKey(Element(3)) != 9 {
+		t.Fail()
+	}
+
+	for i := 99; i >= 0; i-- {
+		if (i == 53) == list.DeleteValue(ComplexElement{i % 10, fmt.Sprint(i)}, sameString) {
+			t.Fail()
+		}
+	}
+	checkStructure(t, &list)
+	if !list.IsEmpty() {
+		t.Fail()
+	}
+}
+
+func TestUpdateKey(t *testing.T) {
+	list := New()
+
+	if _, ok := list.UpdateKey(nil, Element(0)); ok {
+		t.Fail()
+	}
+
+	for i := 0; i < 100; i++ {
+		list.Insert(ComplexElement{i * 10, fmt.Sprint(i)})
+	}
+
+	// Stays in place.
+	node, _ := list.Find(Element(500))
+	if index, ok := list.UpdateKey(node, ComplexElement{505, "in place"}); !ok || index != 50 {
+		t.Fail()
+	}
+	if v, ok := list.Find(Element(505)); !ok || v != node || v.GetValue().(ComplexElement).S != "in place" {
+		t.Fail()
+	}
+
+	// Moves to the front, end and middle, the handle stays valid.
+	for _, key := range []int{-5, 2000, 333, 1, 991} {
+		index, ok := list.UpdateKey(node, ComplexElement{key, "moved"})
+		if !ok {
+			t.Fail()
+		}
+		if v, _ := list.GetByIndex(index); v != node {
+			t.Fail()
+		}
+		if v, ok := list.Find(Element(key)); !ok || v.GetValue().(ComplexElement).S != "moved" {
+			t.Fail()
+		}
+		checkStructure(t, &list)
+	}
+	if list.GetNodeCount() != 100 {
+		t.Fail()
+	}
+
+	// Random moves.
+	for i := 0; i < 10000; i++ {
+		node, _ := list.GetByIndex(rand.Intn(list.GetNodeCount()))
+		list.UpdateKey(node, Element(rand.Intn(1000)))
+	}
+	checkStructure(t, &list)
+
+	list = New()
+	list.SetDuplicatePolicy(RejectDuplicates)
+	list.Insert(Element(1))
+	list.Insert(Element(2))
+	node, _ = list.Find(Element(1))
+	if _, ok := list.UpdateKey(node, Element(2)); ok {
+		t.Fail()
+	}
+	if _, ok := list.UpdateKey(node, FloatElement(1.5)); !ok {
+		t.Fail()
+	}
+	list.Delete(FloatElement(1.5))
+	if _, ok := list.UpdateKey(node, Element(3)); ok {
+		t.Fail()
+	}
+}
+
+func TestHeightAndProbability(t *testing.T) {
+	list := New()
+	if list.GetMaxHeight() != maxLevel || list.GetProbability() != ProbabilityHalf {
+		t.Fail()
+	}
+	list.SetMaxHeight(100)
+	if list.GetMaxHeight() != maxHeight {
+		t.Fail()
+	}
+	list.SetMaxHeight(0)
+	if list.GetMaxHeight() != 1 {
+		t.Fail()
+	}
+	list.SetProbability(1)
+	if list.GetProbability() != ProbabilityHalf {
+		t.Fail()
+	}
+
+	list.SetMaxHeight(3)
+	for _, e := range rand.Perm(1000) {
+		list.Insert(Element(e))
+	}
+	checkStructure(t, &list)
+	for node := list.GetSmallestNode(); node != nil; node = node.next[0] {
+		if node.level > 2 || len(node.next) != node.level+1 {
+			t.Fatalf("wrong tower of height %v", node.level)
+		}
+	}
+
+	const n = 20000
+	for _, p := range []float64{ProbabilityHalf, ProbabilityQuarter, ProbabilityInverseE} {
+		list := NewSeed(1)
+		list.SetProbability(p)
+		for _, e := range rand.Perm(n) {
+			list.Insert(Element(e))
+		}
+		checkStructure(t, &list)
+
+		// Roughly p of all nodes are promoted to the next level.
+		promoted := 0
+		for node := list.GetSmallestNode(); node != nil; node = node.next[0] {
+			if node.level > 0 {
+				promoted++
+			}
+		}
+		if math.Abs(float64(promoted)/n-p) > 0.02 {
+			t.Errorf("%v of the nodes are promoted with probability %v", float64(promoted)/n, p)
+		}
+		for i := 0; i < n; i += 7 {
+			if _, ok := list.Find(Element(i)); !ok {
+				t.Fail()
+			}
+		}
+	}
+}
+
+// zeroSource always returns 0, which is the highest possible level.
+type zeroSource struct{}
+
+func (zeroSource) Int63() int64    { return 0 }
+func (zeroSource) Uint64() uint64  { return 0 }
+func (zeroSource) Seed(seed int64) {}
+
+func TestGenerateLevelLimit(t *testing.T) {
+	list := New()
+	list.random = rand.New(zeroSource{})
+	for _, height := range []int{1, 2, maxLevel, maxHeight} {
+		if level := list.generateLevel(height); level != height-1 {
+			t.Errorf("level %v for height %v", level, height)
+		}
+	}
+	list.SetMaxHeight(maxHeight)
+	list.Insert(Element(1))
+	list.Insert(Element(2))
+	checkStructure(t, &list)
+}
+
+func TestAutoGrow(t *testing.T) {
+	list := New()
+	list.SetMaxHeight(2)
+	for i := 0; i < 1000; i++ {
+		list.Insert(Element(i))
+	}
+	if list.GetMaxHeight() != 2 || list.maxLevel > 1 {
+		t.Fail()
+	}
+
+	list = New()
+	list.SetMaxHeight(2)
+	list.SetAutoGrow(true)
+	for i := 0; i < 1000; i++ {
+		list.Insert(Element(i))
+	}
+	checkStructure(t, &list)
+	// 2^10 is the first capacity above 1000 elements.
+	if list.GetMaxHeight() != 10 {
+		t.Errorf("height %v, expected 10", list.GetMaxHeight())
+	}
+
+	// The settings are kept by new skiplists based on list.
+	clone := list.Clone()
+	clone.Insert(Element(1000))
+	if clone.GetMaxHeight() != 10 || !clone.autoGrow {
+		t.Fail()
+	}
+	right := list.Split(Element(500))
+	if right.GetMaxHeight() != 10 || right.GetProbability() != ProbabilityHalf {
+		t.Fail()
+	}
+}
+
+const benchN = 1 << 20
+
+func BenchmarkFind(b *testing.B) {
+	list := NewSeed(1)
+	for _, e := range rand.Perm(benchN) {
+		list.Insert(Element(e))
+	}
+	keys := rand.Perm(benchN)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.Find(Element(keys[i%benchN]))
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	list := NewSeed(1)
+	keys := rand.Perm(benchN)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.Insert(Element(keys[i%benchN]))
+	}
+}
+
+func BenchmarkDelete(b *testing.B) {
+	list := NewSeed(1)
+	for i := 0; i < b.N; i++ {
+		list.Insert(Element(i))
+	}
+	keys := rand.Perm(b.N)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for _, key := range keys {
+		list.Delete(Element(key))
+	}
+}
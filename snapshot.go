@@ -0,0 +1,257 @@
+package skiplist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+const (
+	snapshotMagic   = "SKPS"
+	snapshotVersion = 1
+	// snapshotBlockSize is the size in bytes, after which a block of elements is written.
+	snapshotBlockSize = 64 << 10
+)
+
+// ErrCorrupted is returned, if a file is truncated, its checksum does not match or its content is invalid.
+var ErrCorrupted = errors.New("skiplist: file is truncated or corrupted")
+
+// writeFrame writes the payload with its length and CRC32 checksum.
+func writeFrame(w io.Writer, payload []byte) error {
+	var header [8]byte
+	binary.LittleEndian.PutUint32(header[:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(header[4:], crc32.ChecksumIEEE(payload))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// readFrame reads one frame written by writeFrame.
+// io.EOF is returned, if r ends exactly before the frame, io.ErrUnexpectedEOF, if it ends within the frame
+// and ErrCorrupted, if the checksum does not match.
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	length := binary.LittleEndian.Uint32(header[:])
+
+	// The buffer only grows with the data actually read, so a corrupted length can not allocate huge amounts of memory.
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(length)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(buf.Bytes()) != binary.LittleEndian.Uint32(header[4:]) {
+		return nil, ErrCorrupted
+	}
+	return buf.Bytes(), nil
+}
+
+// SaveSnapshot writes all elements in increasing order to the file at path.
+// The elements are encoded with the ValueCodec of the skiplist and written in blocks with CRC32 checksums.
+// The file is written to a temporary file first and renamed afterwards, so path either contains the
+// old or the complete new snapshot.
+func (t *SkipList) SaveSnapshot(path string) error {
+	return t.saveSnapshot(path, 0)
+}
+
+// saveSnapshot writes the snapshot with the sequence number of the last write-ahead log record it contains.
+func (t *SkipList) saveSnapshot(path string, sequence uint64) (err error) {
+
+	if t.codec == nil {
+		return ErrNoCodec
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	if err = t.writeSnapshot(file, sequence); err != nil {
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(file.Name(), path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(path))
+	return nil
+}
+
+// writeSnapshot writes the header, all blocks of elements and the final block to w.
+func (t *SkipList) writeSnapshot(w io.Writer, sequence uint64) error {
+
+	bw := bufio.NewWriter(w)
+
+	header := make([]byte, 0, 14)
+	header = append(header, snapshotMagic...)
+	header = append(header, snapshotVersion)
+	header = binary.LittleEndian.AppendUint64(header, math.Float64bits(t.eps))
+	header = append(header, byte(t.duplicatePolicy))
+	if err := writeFrame(bw, header); err != nil {
+		return err
+	}
+
+	// Every block starts with its number of elements. The final block is empty and saves the total count
+	// and the write-ahead log sequence number instead.
+	var block []byte
+	count := 0
+	flush := func() error {
+		if count == 0 {
+			return nil
+		}
+		err := writeFrame(bw, append(binary.AppendUvarint(nil, uint64(count)), block...))
+		block, count = block[:0], 0
+		return err
+	}
+
+	var err error
+	for node := t.startLevels[0]; node != nil; node = node.next[0] {
+		if block, err = appendElement(block, t.codec, node.value); err != nil {
+			return err
+		}
+		count++
+		if len(block) >= snapshotBlockSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+	final := binary.AppendUvarint([]byte{0}, uint64(t.elementCount))
+	if err = writeFrame(bw, binary.AppendUvarint(final, sequence)); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// syncDir makes a rename in the directory durable. Not all platforms support this, so errors are ignored.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// LoadSnapshot replaces all elements of the skiplist with the ones from the snapshot file at path,
+// which are decoded with the ValueCodec of the skiplist. The skiplist is rebuilt in linear time.
+// If the file is truncated, corrupted or has an invalid header or order, an error wrapping ErrCorrupted is returned and the skiplist is not changed.
+func (t *SkipList) LoadSnapshot(path string) error {
+	_, err := t.loadSnapshot(path)
+	return err
+}
+
+// loadSnapshot loads the snapshot and returns the sequence number of the last write-ahead log record it contains.
+func (t *SkipList) loadSnapshot(path string) (sequence uint64, err error) {
+
+	if t.codec == nil {
+		return 0, ErrNoCodec
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	elements, eps, policy, sequence, err := readSnapshot(bufio.NewReader(file), t.codec)
+	if err == nil {
+		err = t.reload(elements, eps, policy)
+		if err != nil {
+			err = fmt.Errorf("%w: %w", ErrCorrupted, err)
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	return sequence, nil
+}
+
+// readSnapshot reads all elements of a snapshot written by writeSnapshot.
+func readSnapshot(r io.Reader, codec ValueCodec) (elements []ListElement, eps float64, policy DuplicatePolicy, sequence uint64, err error) {
+
+	corrupted := func(format string, args ...any) error {
+		return fmt.Errorf("%w: "+format, append([]any{ErrCorrupted}, args...)...)
+	}
+
+	header, err := readFrame(r)
+	if err != nil {
+		return nil, 0, 0, 0, corrupted("header: %v", err)
+	}
+	if len(header) != 14 || string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, 0, 0, 0, corrupted("header: %w", ErrInvalidFormat)
+	}
+	if header[4] != snapshotVersion {
+		return nil, 0, 0, 0, corrupted("header: %w", ErrUnsupportedVersion)
+	}
+	eps = math.Float64frombits(binary.LittleEndian.Uint64(header[5:]))
+	policy = DuplicatePolicy(header[13])
+	if policy > ReplaceDuplicates {
+		return nil, 0, 0, 0, corrupted("header: %w", ErrInvalidFormat)
+	}
+
+	for i := 0; ; i++ {
+		block, err := readFrame(r)
+		if err != nil {
+			return nil, 0, 0, 0, corrupted("block %d: %v", i, err)
+		}
+
+		count, n := binary.Uvarint(block)
+		if n <= 0 {
+			return nil, 0, 0, 0, corrupted("block %d: invalid element count", i)
+		}
+		block = block[n:]
+
+		if count == 0 {
+			// The final block must match the number of elements and end the file.
+			total, n := binary.Uvarint(block)
+			if n <= 0 || total != uint64(len(elements)) {
+				return nil, 0, 0, 0, corrupted("expected %d elements, read %d", total, len(elements))
+			}
+			sequence, m := binary.Uvarint(block[n:])
+			if m <= 0 || n+m != len(block) {
+				return nil, 0, 0, 0, corrupted("invalid final block")
+			}
+			if _, err := r.Read(make([]byte, 1)); err != io.EOF {
+				return nil, 0, 0, 0, corrupted("unexpected data after the final block")
+			}
+			return elements, eps, policy, sequence, nil
+		}
+
+		for ; count > 0; count-- {
+			var e ListElement
+			if e, block, err = readElement(block, codec, eps); err != nil {
+				return nil, 0, 0, 0, corrupted("block %d: %v", i, err)
+			}
+			elements = append(elements, e)
+		}
+		if len(block) != 0 {
+			return nil, 0, 0, 0, corrupted("block %d: unexpected data after the elements", i)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package skiplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.snapshot")
+
+	list := NewEps(0.5)
+	list.SetDuplicatePolicy(RejectDuplicates)
+	if list.SaveSnapshot(path) != ErrNoCodec {
+		t.Fail()
+	}
+	list.SetValueCodec(complexCodec{})
+
+	// Enough data for several blocks.
+	value := strings.Repeat("v", 100)
+	for i := 0; i < 2000; i++ {
+		list.Insert(ComplexElement{i, value})
+	}
+	if err := list.SaveSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded SkipList
+	loaded.SetValueCodec(complexCodec{})
+	if err := loaded.LoadSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	checkStructure(t, &loaded)
+	if loaded.GetNodeCount() != 2000 || loaded.eps != 0.5 || loaded.GetDuplicatePolicy() != RejectDuplicates {
+		t.Fail()
+	}
+	i := 0
+	for v := range loaded.All() {
+		if v.(ComplexElement) != (ComplexElement{i, value}) {
+			t.Fail()
+		}
+		i++
+	}
+
+	// An empty skiplist overwrites the old snapshot.
+	empty := New()
+	empty.SetValueCodec(complexCodec{})
+	if err := empty.SaveSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.LoadSnapshot(path); err != nil || !loaded.IsEmpty() {
+		t.Fail()
+	}
+
+	if !errors.Is(loaded.LoadSnapshot(filepath.Join(t.TempDir(), "missing")), os.ErrNotExist) {
+		t.Fail()
+	}
+}
+
+func TestSnapshotAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list.snapshot")
+
+	list := New()
+	list.SetValueCodec(complexCodec{})
+	list.Insert(ComplexElement{1, "a"})
+	if err := list.SaveSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+
+	// A failing codec must neither change the old snapshot nor leave a temporary file behind.
+	list.Insert(Element(2))
+	if list.SaveSnapshot(path) == nil {
+		t.Fail()
+	}
+	if newData, _ := os.ReadFile(path); string(newData) != string(data) {
+		t.Fail()
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("unexpected files %v", entries)
+	}
+}
+
+func TestSnapshotCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.snapshot")
+
+	list := New()
+	list.SetValueCodec(complexCodec{})
+	for i := 0; i < 2000; i++ {
+		list.Insert(ComplexElement{i, strings.Repeat("v", 100)})
+	}
+	if err := list.SaveSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+
+	loaded := New()
+	loaded.SetValueCodec(complexCodec{})
+	loaded.Insert(ComplexElement{-1, "old"})
+
+	check := func(corrupted []byte) {
+		os.WriteFile(path, corrupted, 0644)
+		if err := loaded.LoadSnapshot(path); !errors.Is(err, ErrCorrupted) {
+			t.Errorf("expected ErrCorrupted, got %v", err)
+		}
+		if loaded.GetNodeCount() != 1 {
+			t.Fail()
+		}
+	}
+
+	for _, size := range []int{0, 3, 20, 100, len(data) / 2, len(data) - 10, len(data) - 1} {
+		check(data[:size])
+	}
+	for _, pos := range []int{10, 30, len(data) / 3, len(data) - 2} {
+		corrupted := append([]byte(nil), data...)
+		corrupted[pos] ^= 0x10
+		check(corrupted)
+	}
+	check(append(append([]byte(nil), data...), 0))
+
+	// Frames with valid checksums, but an invalid header or unsorted elements.
+	frames := func(payloads ...[]byte) []byte {
+		var buf bytes.Buffer
+		for _, payload := range payloads {
+			writeFrame(&buf, payload)
+		}
+		return buf.Bytes()
+	}
+	header := data[8:22]
+	rest := data[22:]
+	for _, pos := range []int{0, 4, 13} {
+		broken := bytes.Clone(header)
+		broken[pos] = 7
+		check(append(frames(broken), rest...))
+	}
+
+	block := binary.AppendUvarint(nil, 2)
+	block, _ = appendElement(block, complexCodec{}, ComplexElement{5, "a"})
+	block, _ = appendElement(block, complexCodec{}, ComplexElement{1, "a"})
+	check(frames(header, block, []byte{0, 2, 0}))
+}
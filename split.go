@@ -0,0 +1,144 @@
+package skiplist
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrDuplicate is returned by Join, if the duplicate policy of the skiplist forbids equal elements at the boundary.
+var ErrDuplicate = errors.New("skiplist: duplicate element")
+
+// Split cuts the skiplist into two at the key of e. All elements smaller than e stay in t,
+// all elements greater or equal to e are moved into the returned skiplist.
+// No nodes are copied, only the links at the cut are changed.
+// Split runs in approx. O(log(n))
+func (t *SkipList) Split(e ListElement) *SkipList {
+
+	if t == nil {
+		return nil
+	}
+
+	right := NewEps(t.eps)
+	right.copySettings(t)
+
+	if t.IsEmpty() || e == nil {
+		return &right
+	}
+
+	key := e.ExtractKey()
+
+	// Find the last node before the cut and its index on every level.
+	var update [maxHeight]*SkipListElement
+	var ranks [maxHeight]int
+	var currentNode *SkipListElement
+	rank := -1
+	for index := t.maxLevel; index >= 0; index-- {
+		for {
+			nextNode := t.nextNode(currentNode, index)
+			if nextNode == nil || key-nextNode.key <= t.eps {
+				break
+			}
+			// Go right
+			rank += *t.width(currentNode, index)
+			currentNode = nextNode
+		}
+		update[index] = currentNode
+		ranks[index] = rank
+	}
+
+	first := t.nextNode(update[0], 0)
+	if first == nil {
+		return &right
+	}
+	first.prev = nil
+
+	leftCount := ranks[0] + 1
+
+	for i := 0; i <= t.maxLevel; i++ {
+		w := t.width(update[i], i)
+
+		if nextNode := t.nextNode(update[i], i); nextNode != nil {
+			right.startLevels[i] = nextNode
+			right.startWidth[i] = ranks[i] + *w - leftCount + 1
+			right.endLevels[i] = t.endLevels[i]
+
+			if update[i] == nil {
+				t.startLevels[i] = nil
+			} else {
+				update[i].next[i] = nil
+			}
+			t.endLevels[i] = update[i]
+		}
+		// The link now spans to the new end of t.
+		*w = leftCount - ranks[i]
+	}
+
+	right.maxLevel = t.maxLevel
+	right.elementCount = t.elementCount - leftCount
+	t.elementCount = leftCount
+
+	// The highest levels might be empty now.
+	for right.maxLevel >= 0 && right.startLevels[right.maxLevel] == nil {
+		right.maxLevel--
+	}
+	for t.maxLevel >= 0 && t.startLevels[t.maxLevel] == nil {
+		t.maxLevel--
+	}
+
+	return &right
+}
+
+// Join appends all elements of other to the end of t and leaves other empty.
+// The smallest element of other must not be smaller than the largest element of t, unless they are equal (eps),
+// otherwise ErrNotSorted is returned and neither skiplist is changed.
+// If the duplicate policy of t is not AllowDuplicates, they must not be equal either, otherwise ErrDuplicate
+// is returned. Only the boundary is checked, so other should use the same duplicate policy as t.
+// No nodes are copied, only the links between the end of t and the start of other are changed.
+// Join runs in approx. O(log(n))
+func (t *SkipList) Join(other *SkipList) error {
+
+	if t == nil || other == nil || t == other || other.IsEmpty() {
+		return nil
+	}
+
+	if !t.IsEmpty() && t.endLevels[0].key-other.startLevels[0].key > t.eps {
+		return ErrNotSorted
+	}
+	if t.duplicatePolicy != AllowDuplicates && !t.IsEmpty() && math.Abs(t.endLevels[0].key-other.startLevels[0].key) <= t.eps {
+		return ErrDuplicate
+	}
+
+	other.startLevels[0].prev = t.endLevels[0]
+
+	top := max(t.maxLevel, other.maxLevel)
+	for i := 0; i <= top; i++ {
+
+		otherStart := other.startLevels[i]
+
+		if t.endLevels[i] == nil || i > t.maxLevel {
+			// Level i of t is empty, it starts with other.
+			if otherStart != nil {
+				t.startLevels[i] = otherStart
+				t.startWidth[i] = t.elementCount + other.startWidth[i]
+				t.endLevels[i] = other.endLevels[i]
+			}
+			continue
+		}
+
+		w := t.width(t.endLevels[i], i)
+		if otherStart == nil {
+			// The link to the end now spans over all of other.
+			*w += other.elementCount
+			continue
+		}
+		t.endLevels[i].next[i] = otherStart
+		*w += other.startWidth[i] - 1
+		t.endLevels[i] = other.endLevels[i]
+	}
+
+	t.maxLevel = top
+	t.elementCount += other.elementCount
+	other.clear()
+
+	return nil
+}
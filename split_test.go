@@ -0,0 +1,118 @@
+package skiplist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	for _, key := range []int{-10, 0, 1, 500, 999, 1000, 2000} {
+		list := New()
+		for i := 0; i < 1000; i++ {
+			list.Insert(Element(i))
+		}
+
+		right := list.Split(Element(key))
+		checkStructure(t, &list)
+		checkStructure(t, right)
+
+		expected := min(max(key, 0), 1000)
+		if list.GetNodeCount() != expected || right.GetNodeCount() != 1000-expected {
+			t.Errorf("split at %v: %v and %v elements", key, list.GetNodeCount(), right.GetNodeCount())
+		}
+		if !list.IsEmpty() && list.GetLargestNode().GetValue().(Element) != Element(expected-1) {
+			t.Fail()
+		}
+		if !right.IsEmpty() && right.GetSmallestNode().GetValue().(Element) != Element(expected) {
+			t.Fail()
+		}
+
+		// Both parts are still usable.
+		list.Insert(Element(-1))
+		right.Insert(Element(5000))
+		right.Delete(Element(999))
+		checkStructure(t, &list)
+		checkStructure(t, right)
+	}
+
+	// Equal elements all move into the right part.
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Insert(Element(i % 10))
+	}
+	right := list.Split(Element(5))
+	if list.GetNodeCount() != 50 || right.CountKey(Element(5)) != 10 {
+		t.Fail()
+	}
+}
+
+func TestJoin(t *testing.T) {
+	for _, key := range []int{0, 1, 500, 999, 1000} {
+		list := New()
+		other := New()
+		for i := 0; i < 1000; i++ {
+			if i < key {
+				list.Insert(Element(i))
+			} else {
+				other.Insert(Element(i))
+			}
+		}
+
+		if err := list.Join(&other); err != nil {
+			t.Fail()
+		}
+		checkStructure(t, &list)
+		checkStructure(t, &other)
+		if list.GetNodeCount() != 1000 || !other.IsEmpty() {
+			t.Fail()
+		}
+		for i := 0; i < 1000; i++ {
+			if index, ok := list.Rank(Element(i)); !ok || index != i {
+				t.Fail()
+			}
+		}
+	}
+
+	list := newFromKeys(1, 2, 3)
+	other := newFromKeys(2, 5)
+	if list.Join(other) != ErrNotSorted || list.GetNodeCount() != 3 || other.GetNodeCount() != 2 {
+		t.Fail()
+	}
+
+	// Equal elements at the boundary are only joined, if the duplicate policy allows them.
+	for _, policy := range []DuplicatePolicy{AllowDuplicates, RejectDuplicates, ReplaceDuplicates} {
+		list := newFromKeys(1)
+		other := newFromKeys(1, 2)
+		list.SetDuplicatePolicy(policy)
+		err := list.Join(other)
+		switch {
+		case policy == AllowDuplicates && (err != nil || list.GetNodeCount() != 3):
+			t.Fail()
+		case policy != AllowDuplicates && (err != ErrDuplicate || list.GetNodeCount() != 1 || other.GetNodeCount() != 2):
+			t.Errorf("joined equal elements with policy %v", policy)
+		}
+		checkStructure(t, list)
+	}
+}
+
+func TestSplitJoin(t *testing.T) {
+	list := New()
+	for _, e := range rand.Perm(10000) {
+		list.Insert(Element(e))
+	}
+
+	// Cut into many parts and glue them back together.
+	var parts []*SkipList
+	for _, key := range []int{8000, 6000, 4000, 2000, 10} {
+		parts = append(parts, list.Split(Element(key)))
+	}
+	for i := len(parts) - 1; i >= 0; i-- {
+		if list.Join(parts[i]) != nil {
+			t.Fail()
+		}
+	}
+	checkStructure(t, &list)
+	if list.GetNodeCount() != 10000 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,295 @@
+package skiplist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SyncPolicy defines, when the write-ahead log is synced to disk.
+type SyncPolicy int
+
+const (
+	// SyncAlways syncs the log after every record. No acknowledged operation is lost on a crash.
+	SyncAlways SyncPolicy = iota
+	// SyncBatched syncs the log after every WALOptions.BatchSize records and on Sync and Close.
+	// A crash loses at most the last batch.
+	SyncBatched
+	// SyncNone leaves syncing to the operating system. A crash of the process loses nothing,
+	// a crash of the machine might lose any operation since the last Sync.
+	SyncNone
+)
+
+// Operations saved in write-ahead log records.
+const (
+	walInsert byte = iota + 1
+	walDelete
+	walChangeValue
+)
+
+// WALOptions configures a write-ahead log.
+type WALOptions struct {
+	// Sync defines, when the log is synced to disk.
+	Sync SyncPolicy
+	// BatchSize is the number of records after which the log is synced with SyncBatched.
+	BatchSize int
+	// SnapshotPath is the path of the snapshot, that Checkpoint writes. It is loaded before the log is replayed.
+	// If it is empty, Checkpoint is not available.
+	SnapshotPath string
+}
+
+// walFile is the part of *os.File, that is used by WAL.
+type walFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+	Truncate(size int64) error
+	Sync() error
+}
+
+// ErrNoSnapshotPath is returned by Checkpoint, if no snapshot path is configured.
+var ErrNoSnapshotPath = errors.New("skiplist: no snapshot path set")
+
+// WAL is a write-ahead log for a skiplist. Every modification is appended to the log as a framed
+// record with a CRC32 checksum before it is applied to the skiplist, so the skiplist can be restored after a crash.
+// The elements are encoded with the ValueCodec of the skiplist.
+// Like SkipList, WAL is not safe for concurrent use.
+type WAL struct {
+	list     *SkipList
+	file     walFile
+	options  WALOptions
+	size     int64
+	sequence uint64
+	unsynced int
+}
+
+// OpenWAL opens or creates the write-ahead log at path and restores the skiplist from it.
+// If options.SnapshotPath is set and the snapshot exists, it is loaded first. Afterwards all records of the log,
+// that are not already part of the snapshot, are applied to the skiplist in order.
+// A torn or corrupted record at the end of the log (e.g. from a crash while writing) is cut off together with
+// everything after it.
+func OpenWAL(path string, list *SkipList, options WALOptions) (*WAL, error) {
+
+	if list.codec == nil {
+		return nil, ErrNoCodec
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 1
+	}
+
+	w := &WAL{
+		list:    list,
+		options: options,
+	}
+
+	if options.SnapshotPath != "" {
+		sequence, err := list.loadSnapshot(options.SnapshotPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		w.sequence = sequence
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	w.file = file
+
+	if err := w.replay(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return w, nil
+}
+
+// replay applies all records of the log and truncates it after the last valid one.
+func (w *WAL) replay() error {
+
+	r := bufio.NewReader(w.file)
+	for {
+		payload, err := readFrame(r)
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF || err == ErrCorrupted {
+			// Cut off the torn tail.
+			if err := w.file.Truncate(w.size); err != nil {
+				return err
+			}
+			if err := w.file.Sync(); err != nil {
+				return err
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		sequence, n := binary.Uvarint(payload)
+		if n <= 0 || len(payload) == n {
+			return ErrCorrupted
+		}
+		op := payload[n]
+		e, rest, err := readElement(payload[n+1:], w.list.codec, w.list.eps)
+		if err != nil || len(rest) != 0 {
+			return ErrCorrupted
+		}
+
+		// Records up to the sequence number of the snapshot are already applied.
+		if sequence > w.sequence {
+			if !w.apply(op, e) {
+				return ErrCorrupted
+			}
+			w.sequence = sequence
+		}
+		w.size += int64(8 + len(payload))
+	}
+
+	_, err := w.file.Seek(w.size, io.SeekStart)
+	return err
+}
+
+// apply applies one operation to the skiplist. ok is false for an unknown operation.
+func (w *WAL) apply(op byte, e ListElement) (ok bool) {
+	switch op {
+	case walInsert:
+		w.list.Insert(e)
+	case walDelete:
+		w.list.Delete(e)
+	case walChangeValue:
+		if elem, found := w.list.Find(e); found {
+			w.list.ChangeValue(elem, e)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// write appends one record to the log and syncs it according to the sync policy.
+// If writing fails, the log is cut back to the last record, so it stays readable.
+func (w *WAL) write(op byte, e ListElement) error {
+
+	payload := binary.AppendUvarint(nil, w.sequence+1)
+	payload = append(payload, op)
+	payload, err := appendElement(payload, w.list.codec, e)
+	if err != nil {
+		return err
+	}
+
+	var frame bytes.Buffer
+	writeFrame(&frame, payload)
+	if _, err := w.file.Write(frame.Bytes()); err != nil {
+		w.truncate(w.size)
+		return err
+	}
+	w.size += int64(frame.Len())
+	w.sequence++
+	w.unsynced++
+
+	switch {
+	case w.options.Sync == SyncAlways,
+		w.options.Sync == SyncBatched && w.unsynced >= w.options.BatchSize:
+		if err := w.Sync(); err != nil {
+			// The operation is not applied, so it must not be replayed either.
+			w.size -= int64(frame.Len())
+			w.sequence--
+			w.unsynced--
+			w.truncate(w.size)
+			return err
+		}
+	}
+	return nil
+}
+
+// truncate cuts the log back to size, so the next record is appended after the last valid one.
+func (w *WAL) truncate(size int64) {
+	w.file.Truncate(size)
+	w.file.Seek(size, io.SeekStart)
+}
+
+// List returns the skiplist of the log. It must only be modified through the log!
+func (w *WAL) List() *SkipList {
+	return w.list
+}
+
+// Insert logs and inserts the given ListElement into the skiplist.
+// index and ok are the results of SkipList.Insert. The skiplist is not changed, if logging fails.
+func (w *WAL) Insert(e ListElement) (index int, ok bool, err error) {
+	if err = w.write(walInsert, e); err != nil {
+		return
+	}
+	index, ok = w.list.Insert(e)
+	return
+}
+
+// Delete logs and removes an element equal to e from the skiplist, if there is one.
+// The skiplist is not changed, if logging fails.
+func (w *WAL) Delete(e ListElement) error {
+	if err := w.write(walDelete, e); err != nil {
+		return err
+	}
+	w.list.Delete(e)
+	return nil
+}
+
+// ChangeValue logs and replaces the value of the element with the same key as newValue.
+// ok is an indicator, wether there was such an element and the value is actually changed.
+// The skiplist is not changed, if logging fails.
+func (w *WAL) ChangeValue(newValue ListElement) (ok bool, err error) {
+	if err = w.write(walChangeValue, newValue); err != nil {
+		return
+	}
+	if elem, found := w.list.Find(newValue); found {
+		ok = w.list.ChangeValue(elem, newValue)
+	}
+	return
+}
+
+// Sync syncs all records to disk.
+func (w *WAL) Sync() error {
+	if w.unsynced == 0 {
+		return nil
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.unsynced = 0
+	return nil
+}
+
+// Checkpoint writes a snapshot of the skiplist to WALOptions.SnapshotPath and truncates the log afterwards.
+// The snapshot saves the sequence number of the last record, so a crash between both steps does not apply
+// any record twice.
+func (w *WAL) Checkpoint() error {
+
+	if w.options.SnapshotPath == "" {
+		return ErrNoSnapshotPath
+	}
+
+	if err := w.list.saveSnapshot(w.options.SnapshotPath, w.sequence); err != nil {
+		return err
+	}
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w.size = 0
+	w.unsynced = 0
+	return w.file.Sync()
+}
+
+// Close syncs and closes the log. The skiplist stays usable, but is not logged anymore.
+func (w *WAL) Close() error {
+	err := w.Sync()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
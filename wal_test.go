@@ -0,0 +1,215 @@
+package skiplist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// openTestWAL opens the log at path with a new skiplist.
+func openTestWAL(t *testing.T, path string, options WALOptions) *WAL {
+	list := New()
+	list.SetValueCodec(complexCodec{})
+	w, err := OpenWAL(path, &list, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.wal")
+
+	list := New()
+	if _, err := OpenWAL(path, &list, WALOptions{}); err != ErrNoCodec {
+		t.Fail()
+	}
+
+	for _, sync := range []SyncPolicy{SyncAlways, SyncBatched, SyncNone} {
+		os.Remove(path)
+
+		w := openTestWAL(t, path, WALOptions{Sync: sync, BatchSize: 10})
+		for i := 0; i < 100; i++ {
+			if _, ok, err := w.Insert(ComplexElement{i, "a"}); !ok || err != nil {
+				t.Fail()
+			}
+		}
+		w.Insert(ComplexElement{5, "b"})
+		for i := 0; i < 100; i += 2 {
+			if err := w.Delete(ComplexElement{i, ""}); err != nil {
+				t.Fail()
+			}
+		}
+		if ok, err := w.ChangeValue(ComplexElement{7, "c"}); !ok || err != nil {
+			t.Fail()
+		}
+		if ok, _ := w.ChangeValue(ComplexElement{8, "c"}); ok {
+			t.Fail()
+		}
+		// A failing codec does not change the skiplist.
+		if _, _, err := w.Insert(Element(1000)); err == nil || w.List().GetNodeCount() != 51 {
+			t.Fail()
+		}
+		expected := w.List()
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		w = openTestWAL(t, path, WALOptions{Sync: sync})
+		checkStructure(t, w.List())
+		if !equalComplex(w.List(), expected) {
+			t.Errorf("replayed skiplist differs with sync policy %v", sync)
+		}
+		w.Close()
+	}
+}
+
+// equalComplex checks, that both skiplists contain the same ComplexElements in the same order.
+func equalComplex(a, b *SkipList) bool {
+	if a.GetNodeCount() != b.GetNodeCount() {
+		return false
+	}
+	node := b.GetSmallestNode()
+	for v := range a.All() {
+		if v.(ComplexElement) != node.GetValue().(ComplexElement) {
+			return false
+		}
+		node = node.next[0]
+	}
+	return true
+}
+
+func TestWALTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.wal")
+
+	w := openTestWAL(t, path, WALOptions{Sync: SyncNone})
+	for i := 0; i < 10; i++ {
+		w.Insert(ComplexElement{i, "value"})
+	}
+	w.Close()
+
+	data, _ := os.ReadFile(path)
+	recordSize := len(data) / 10
+
+	// A torn last record is cut off and new records are appended after the last valid one.
+	os.WriteFile(path, data[:len(data)-3], 0644)
+	w = openTestWAL(t, path, WALOptions{})
+	if w.List().GetNodeCount() != 9 {
+		t.Errorf("expected 9 elements, got %v", w.List().GetNodeCount())
+	}
+	if info, _ := os.Stat(path); info.Size() != int64(9*recordSize) {
+		t.Fail()
+	}
+	w.Insert(ComplexElement{100, "value"})
+	w.Close()
+
+	w = openTestWAL(t, path, WALOptions{})
+	if w.List().GetNodeCount() != 10 || w.List().CountKey(Element(100)) != 1 {
+		t.Fail()
+	}
+	w.Close()
+
+	// A corrupted record is cut off with everything after it.
+	data, _ = os.ReadFile(path)
+	data[5*recordSize+10] ^= 0xff
+	os.WriteFile(path, data, 0644)
+	w = openTestWAL(t, path, WALOptions{})
+	if w.List().GetNodeCount() != 5 {
+		t.Errorf("expected 5 elements, got %v", w.List().GetNodeCount())
+	}
+	w.Close()
+}
+
+func TestWALCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "list.wal")
+	options := WALOptions{SnapshotPath: filepath.Join(dir, "list.snapshot")}
+
+	w := openTestWAL(t, path, WALOptions{})
+	if w.Checkpoint() != ErrNoSnapshotPath {
+		t.Fail()
+	}
+	w.Close()
+	os.Remove(path)
+
+	w = openTestWAL(t, path, options)
+	for i := 0; i < 50; i++ {
+		w.Insert(ComplexElement{i, "a"})
+	}
+	if err := w.Checkpoint(); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(path); info.Size() != 0 {
+		t.Fail()
+	}
+	for i := 50; i < 60; i++ {
+		w.Insert(ComplexElement{i, "a"})
+	}
+	if err := w.Delete(ComplexElement{0, ""}); err != nil {
+		t.Fail()
+	}
+	w.Close()
+
+	w = openTestWAL(t, path, options)
+	if w.List().GetNodeCount() != 59 || w.List().CountKey(Element(0)) != 0 {
+		t.Errorf("expected 59 elements, got %v", w.List().GetNodeCount())
+	}
+
+	// A crash after writing the snapshot, but before truncating the log, must not apply any record twice.
+	w.Insert(ComplexElement{0, "b"})
+	if err := w.List().saveSnapshot(options.SnapshotPath, w.sequence); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	w = openTestWAL(t, path, options)
+	if w.List().GetNodeCount() != 60 || w.List().CountKey(Element(0)) != 1 {
+		t.Errorf("expected 60 elements, got %v", w.List().GetNodeCount())
+	}
+	w.Close()
+}
+
+// failingSync is a log file, whose Sync always fails.
+type failingSync struct {
+	*os.File
+}
+
+func (failingSync) Sync() error {
+	return errors.New("sync failed")
+}
+
+func TestWALSyncFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.wal")
+
+	for _, sync := range []SyncPolicy{SyncAlways, SyncBatched} {
+		os.Remove(path)
+
+		w := openTestWAL(t, path, WALOptions{Sync: sync, BatchSize: 2})
+		w.Insert(ComplexElement{1, "a"})
+		w.Insert(ComplexElement{2, "a"})
+
+		// A failed sync neither changes the skiplist nor leaves the record in the log.
+		file := w.file
+		w.file = failingSync{file.(*os.File)}
+		w.Insert(ComplexElement{3, "a"})
+		if _, _, err := w.Insert(ComplexElement{4, "a"}); err == nil && sync == SyncBatched {
+			t.Fail()
+		}
+		if err := w.Delete(ComplexElement{1, ""}); err == nil {
+			t.Fail()
+		}
+		if ok, err := w.ChangeValue(ComplexElement{2, "b"}); ok || err == nil {
+			t.Fail()
+		}
+		w.file = file
+		expected := w.List()
+		w.Close()
+
+		w = openTestWAL(t, path, WALOptions{})
+		if !equalComplex(w.List(), expected) {
+			t.Errorf("replayed skiplist differs with sync policy %v", sync)
+		}
+		w.Close()
+	}
+}